@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/ImTomEddy/truelayer-go/truelayer"
 	"github.com/ImTomEddy/truelayer-go/truelayer/providers"
@@ -19,6 +20,29 @@ type Config struct {
 	Sandbox      bool   `env:"TRUELAYER_SANDBOX,default=true"`
 }
 
+// PendingAuths holds the PKCE code verifier for each authentication link that
+// has been generated, keyed by the link's state.
+type PendingAuths struct {
+	mu        sync.Mutex
+	verifiers map[string]string
+}
+
+func (p *PendingAuths) Add(link *truelayer.AuthenticationLink) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.verifiers[link.State] = link.CodeVerifier
+}
+
+// Take returns and removes the code verifier for the state so that each state
+// can only be used once.
+func (p *PendingAuths) Take(state string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	verifier, ok := p.verifiers[state]
+	delete(p.verifiers, state)
+	return verifier, ok
+}
+
 type TemplateData struct {
 	AccountID      string
 	Accounts       []truelayer.Account
@@ -42,20 +66,31 @@ func main() {
 	}
 	redirectURL.Path = config.RedirectPath
 
-	t := truelayer.New(config.ClientID, config.ClientSecret, config.Sandbox)
-	link, _ := t.GetAuthenticationLink([]string{providers.UKMock, providers.UKOAuthAll, providers.UKOpenBankingAll}, []string{truelayer.PermissionAll}, redirectURL, true)
+	t := truelayer.New(config.ClientID, config.ClientSecret,
+		truelayer.WithSandbox(config.Sandbox),
+		truelayer.WithLogger(log.Default()),
+	)
+	pending := &PendingAuths{verifiers: map[string]string{}}
 
-	http.HandleFunc("/", handle(t, link, redirectURL))
+	http.HandleFunc("/", handle(t, pending, redirectURL))
 
 	http.ListenAndServe(":"+redirectURL.Port(), nil)
 }
 
-func handle(t *truelayer.TrueLayer, redirectURL string, callbackURL *url.URL) func(http.ResponseWriter, *http.Request) {
+func handle(t truelayer.Client, pending *PendingAuths, callbackURL *url.URL) func(http.ResponseWriter, *http.Request) {
 	return func(rw http.ResponseWriter, r *http.Request) {
 		log.Println("Recieved Request")
 		if r.Method != http.MethodPost {
 			log.Println("Not Post - Redirecting")
-			http.Redirect(rw, r, redirectURL, http.StatusMovedPermanently)
+			link, err := t.GetSecureAuthenticationLink([]string{providers.UKMock, providers.UKOAuthAll, providers.UKOpenBankingAll}, []string{truelayer.PermissionAll}, callbackURL, true)
+			if err != nil {
+				log.Println(err.Error())
+				rw.Write([]byte(err.Error()))
+				return
+			}
+
+			pending.Add(link)
+			http.Redirect(rw, r, link.URL, http.StatusFound)
 			return
 		}
 
@@ -81,8 +116,20 @@ func handle(t *truelayer.TrueLayer, redirectURL string, callbackURL *url.URL) fu
 			rw.Write([]byte("code is empty"))
 		}
 
+		log.Println("Checking State")
+		state := r.PostForm.Get("state")
+		verifier, ok := pending.Take(state)
+		if !ok {
+			log.Println("unknown state")
+			rw.Write([]byte("unknown state"))
+			return
+		}
+
 		log.Println("Getting Access Token")
-		token, err := t.GetAccessToken(code, callbackURL)
+		token, err := t.GetAccessToken(code, callbackURL, &truelayer.AccessTokenOptions{
+			CodeVerifier: verifier,
+			State:        state,
+		})
 		if err != nil {
 			log.Println(err.Error())
 			rw.Write([]byte(err.Error()))
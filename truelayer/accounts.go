@@ -1,8 +1,10 @@
 package truelayer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"time"
 )
@@ -20,82 +22,220 @@ const (
 )
 
 type Account struct {
-	UpdateTimestamp time.Time `json:"update_timestamp"`
-	AccountID       string    `json:"account_id"`
-	AccountType     string    `json:"account_type"`
-	DisplayName     string    `json:"display_name"`
-	Currency        string    `json:"currency"`
-	AccountNumber   struct {
-		Iban     string `json:"iban"`
-		Number   string `json:"number"`
-		SortCode string `json:"sort_code"`
-		SwiftBic string `json:"swift_bic"`
-	} `json:"account_number"`
-	Provider struct {
-		ProviderID string `json:"provider_id"`
-	} `json:"provider"`
+	UpdateTimestamp time.Time     `json:"update_timestamp"`
+	AccountID       string        `json:"account_id"`
+	AccountType     AccountType   `json:"account_type"`
+	DisplayName     string        `json:"display_name"`
+	Currency        string        `json:"currency"`
+	AccountNumber   AccountNumber `json:"account_number"`
+	Provider        Provider      `json:"provider"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+	// Raw is the JSON the account was decoded from, only set when the client was
+	// created with WithRawJSON.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the account, keeping any fields which are not modelled.
+func (a *Account) UnmarshalJSON(data []byte) error {
+	type alias Account
+	return unmarshalModel(data, (*alias)(a), &a.Extra)
+}
+
+type AccountNumber struct {
+	Iban     string `json:"iban"`
+	Number   string `json:"number"`
+	SortCode string `json:"sort_code"`
+	SwiftBic string `json:"swift_bic"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the account number, keeping any fields which are not
+// modelled.
+func (n *AccountNumber) UnmarshalJSON(data []byte) error {
+	type alias AccountNumber
+	return unmarshalModel(data, (*alias)(n), &n.Extra)
+}
+
+type Provider struct {
+	ProviderID  string `json:"provider_id"`
+	DisplayName string `json:"display_name"`
+	LogoURI     string `json:"logo_uri"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the provider, keeping any fields which are not
+// modelled.
+func (p *Provider) UnmarshalJSON(data []byte) error {
+	type alias Provider
+	return unmarshalModel(data, (*alias)(p), &p.Extra)
 }
 
 type AccountBalance struct {
 	Currency        string    `json:"currency"`
-	Available       float64   `json:"available"`
-	Current         float64   `json:"current"`
-	Overdraft       float64   `json:"overdraft"`
+	Available       Money     `json:"available"`
+	Current         Money     `json:"current"`
+	Overdraft       Money     `json:"overdraft"`
 	UpdateTimestamp time.Time `json:"update_timestamp"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+	// Raw is the JSON the balance was decoded from, only set when the client was
+	// created with WithRawJSON.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the balance, setting the currency of the amounts and
+// keeping any fields which are not modelled.
+func (b *AccountBalance) UnmarshalJSON(data []byte) error {
+	type alias AccountBalance
+	return unmarshalModel(data, (*alias)(b), &b.Extra, &b.Available, &b.Current, &b.Overdraft)
 }
 
 type AccountTransaction struct {
-	TransactionID                   string   `json:"transaction_id"`
-	NormalisedProviderTransactionID string   `json:"normalised_provider_transaction_id"`
-	ProviderTransactionID           string   `json:"provider_transaction_id"`
-	Timestamp                       string   `json:"timestamp"`
-	Description                     string   `json:"description"`
-	Amount                          float64  `json:"amount"`
-	Currency                        string   `json:"currency"`
-	TransactionType                 string   `json:"transaction_type"`
-	TransactionCategory             string   `json:"transaction_category"`
-	TransactionClassification       []string `json:"transaction_classification"`
-	MerchantName                    string   `json:"merchant_name"`
-	RunningBalance                  struct {
-		Amount   float64 `json:"amount"`
-		Currency string  `json:"currency"`
-	} `json:"running_balance"`
-	Meta struct {
-		BankTransactionID           string `json:"bank_transaction_id"`
-		ProviderTransactionCategory string `json:"provider_transaction_category"`
-	} `json:"meta"`
+	TransactionID                   string                      `json:"transaction_id"`
+	NormalisedProviderTransactionID string                      `json:"normalised_provider_transaction_id"`
+	ProviderTransactionID           string                      `json:"provider_transaction_id"`
+	Timestamp                       Timestamp                   `json:"timestamp"`
+	Description                     string                      `json:"description"`
+	Amount                          Money                       `json:"amount"`
+	Currency                        string                      `json:"currency"`
+	TransactionType                 TransactionType             `json:"transaction_type"`
+	TransactionCategory             TransactionCategory         `json:"transaction_category"`
+	TransactionClassification       []TransactionClassification `json:"transaction_classification"`
+	MerchantName                    string                      `json:"merchant_name"`
+	RunningBalance                  RunningBalance              `json:"running_balance"`
+	Meta                            AccountTransactionMeta      `json:"meta"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+	// Raw is the JSON the transaction was decoded from, only set when the client
+	// was created with WithRawJSON.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the transaction, setting the currency of the amounts
+// and keeping any fields which are not modelled.
+func (tx *AccountTransaction) UnmarshalJSON(data []byte) error {
+	type alias AccountTransaction
+	return unmarshalModel(data, (*alias)(tx), &tx.Extra, &tx.Amount)
+}
+
+type RunningBalance struct {
+	Amount   Money  `json:"amount"`
+	Currency string `json:"currency"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the running balance, setting the currency of the
+// amount and keeping any fields which are not modelled.
+func (b *RunningBalance) UnmarshalJSON(data []byte) error {
+	type alias RunningBalance
+	return unmarshalModel(data, (*alias)(b), &b.Extra, &b.Amount)
+}
+
+type AccountTransactionMeta struct {
+	BankTransactionID           string `json:"bank_transaction_id"`
+	ProviderTransactionCategory string `json:"provider_transaction_category"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the transaction metadata, keeping any fields which are
+// not modelled.
+func (m *AccountTransactionMeta) UnmarshalJSON(data []byte) error {
+	type alias AccountTransactionMeta
+	return unmarshalModel(data, (*alias)(m), &m.Extra)
 }
 
 type AccountStandingOrder struct {
-	Frequency string    `json:"frequency"`
-	Status    string    `json:"status"`
-	Timestamp time.Time `json:"timestamp"`
-	Currency  string    `json:"currency"`
-	Meta      struct {
-		ProviderAccountID string `json:"provider_account_id"`
-	} `json:"meta"`
-	NextPaymentDate    time.Time `json:"next_payment_date"`
-	NextPaymentAmount  float64   `json:"next_payment_amount"`
-	FirstPaymentDate   time.Time `json:"first_payment_date"`
-	FirstPaymentAmount float64   `json:"first_payment_amount"`
-	FinalPaymentDate   time.Time `json:"final_payment_date"`
-	FinalPaymentAmount float64   `json:"final_payment_amount"`
-	Reference          string    `json:"reference"`
-	Payee              string    `json:"payee"`
+	Frequency          StandingOrderFrequency   `json:"frequency"`
+	Status             StandingOrderStatus      `json:"status"`
+	Timestamp          Timestamp                `json:"timestamp"`
+	Currency           string                   `json:"currency"`
+	Meta               AccountStandingOrderMeta `json:"meta"`
+	NextPaymentDate    Timestamp                `json:"next_payment_date"`
+	NextPaymentAmount  Money                    `json:"next_payment_amount"`
+	FirstPaymentDate   Timestamp                `json:"first_payment_date"`
+	FirstPaymentAmount Money                    `json:"first_payment_amount"`
+	FinalPaymentDate   Timestamp                `json:"final_payment_date"`
+	FinalPaymentAmount Money                    `json:"final_payment_amount"`
+	Reference          string                   `json:"reference"`
+	Payee              string                   `json:"payee"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+	// Raw is the JSON the standing order was decoded from, only set when the
+	// client was created with WithRawJSON.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the standing order, setting the currency of the
+// amounts and keeping any fields which are not modelled.
+func (so *AccountStandingOrder) UnmarshalJSON(data []byte) error {
+	type alias AccountStandingOrder
+	return unmarshalModel(data, (*alias)(so), &so.Extra, &so.NextPaymentAmount, &so.FirstPaymentAmount, &so.FinalPaymentAmount)
+}
+
+type AccountStandingOrderMeta struct {
+	ProviderAccountID string `json:"provider_account_id"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the standing order metadata, keeping any fields which
+// are not modelled.
+func (m *AccountStandingOrderMeta) UnmarshalJSON(data []byte) error {
+	type alias AccountStandingOrderMeta
+	return unmarshalModel(data, (*alias)(m), &m.Extra)
 }
 
 type AccountDirectDebit struct {
-	DirectDebitID            string    `json:"direct_debit_id"`
-	Timestamp                time.Time `json:"timestamp"`
-	Name                     string    `json:"name"`
-	Status                   string    `json:"status"`
-	PreviousPaymentTimestamp time.Time `json:"previous_payment_timestamp"`
-	PreviousPaymentAmount    float64   `json:"previous_payment_amount"`
-	Currency                 string    `json:"currency"`
-	Meta                     struct {
-		ProviderMandateIdentification string `json:"provider_mandate_identification"`
-		ProviderAccountID             string `json:"provider_account_id"`
-	} `json:"meta"`
+	DirectDebitID            string                 `json:"direct_debit_id"`
+	Timestamp                Timestamp              `json:"timestamp"`
+	Name                     string                 `json:"name"`
+	Status                   DirectDebitStatus      `json:"status"`
+	PreviousPaymentTimestamp Timestamp              `json:"previous_payment_timestamp"`
+	PreviousPaymentAmount    Money                  `json:"previous_payment_amount"`
+	Currency                 string                 `json:"currency"`
+	Meta                     AccountDirectDebitMeta `json:"meta"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+	// Raw is the JSON the direct debit was decoded from, only set when the client
+	// was created with WithRawJSON.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the direct debit, setting the currency of the amount
+// and keeping any fields which are not modelled.
+func (dd *AccountDirectDebit) UnmarshalJSON(data []byte) error {
+	type alias AccountDirectDebit
+	return unmarshalModel(data, (*alias)(dd), &dd.Extra, &dd.PreviousPaymentAmount)
+}
+
+type AccountDirectDebitMeta struct {
+	ProviderMandateIdentification string `json:"provider_mandate_identification"`
+	ProviderAccountID             string `json:"provider_account_id"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the direct debit metadata, keeping any fields which are
+// not modelled.
+func (m *AccountDirectDebitMeta) UnmarshalJSON(data []byte) error {
+	type alias AccountDirectDebitMeta
+	return unmarshalModel(data, (*alias)(m), &m.Extra)
 }
 
 type AccountOptions struct {
@@ -112,13 +252,47 @@ type AccountOptions struct {
 //   - list of accounts
 //   - errors from the api request
 func (t *TrueLayer) GetAccounts(accessToken string) ([]Account, error) {
+	return t.GetAccountsWithContext(context.Background(), accessToken)
+}
+
+// GetAccountsWithContext is the same as GetAccounts but uses the provided
+// context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the accounts from
+//
+// returns
+//   - list of accounts
+//   - errors from the api request
+func (t *TrueLayer) GetAccountsWithContext(ctx context.Context, accessToken string) ([]Account, error) {
 	u, err := buildURL(t.getBaseURL(), EndpointDataV1Accounts)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return t.getAccounts(u, accessToken)
+	return t.getAccounts(ctx, u, accessToken)
+}
+
+// GetAccountsWithTokenSource is the same as GetAccountsWithContext but takes
+// the access token from the provided TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//
+// returns
+//   - list of accounts
+//   - errors from the api request
+func (t *TrueLayer) GetAccountsWithTokenSource(ctx context.Context, source *TokenSource) ([]Account, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetAccountsWithContext(ctx, accessToken)
 }
 
 // GetAccountsAsync triggers an async request to TrueLayer to get a list of all
@@ -132,7 +306,22 @@ func (t *TrueLayer) GetAccounts(accessToken string) ([]Account, error) {
 //   - truelayer response
 //   - errors from the api request
 func (t *TrueLayer) GetAccountsAsync(accessToken string, webhookURI string) (*AsyncRequestResponse, error) {
-	return t.doAsyncAccountRequest(EndpointDataV1Accounts, accessToken, webhookURI, nil)
+	return t.GetAccountsAsyncWithContext(context.Background(), accessToken, webhookURI)
+}
+
+// GetAccountsAsyncWithContext is the same as GetAccountsAsync but uses the
+// provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetAccountsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, EndpointDataV1Accounts, accessToken, webhookURI, nil)
 }
 
 // GetAccountsAsyncRequest takes the result from a Webhook request and sends a
@@ -142,23 +331,36 @@ func (t *TrueLayer) GetAccountsAsync(accessToken string, webhookURI string) (*As
 //   - accessToken - the access token associated to the webhook request
 //   - webhook - the webhook request to fetch data from
 func (t *TrueLayer) GetAccountsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]Account, error) {
-	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1Results, webhook.TaskID))
+	return t.GetAccountsAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetAccountsAsyncRequestWithContext is the same as GetAccountsAsyncRequest but
+// uses the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]Account, error) {
+	resp := AccountsResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return t.getAccounts(u, accessToken)
+	return resp.Results, nil
 }
 
 // getAccounts takes the Account data URL then does an authenticated GET request
 // decoding the response and returning the correct data structure.
 //
 // params
+//   - ctx - context for the request
 //   - u - the URL to request
 //   - accessToken - the account's associated access token
-func (t *TrueLayer) getAccounts(u *url.URL, accessToken string) ([]Account, error) {
-	res, err := t.doAuthorizedGetRequest(u, accessToken)
+func (t *TrueLayer) getAccounts(ctx context.Context, u *url.URL, accessToken string) ([]Account, error) {
+	res, err := t.doAuthorizedGetRequest(ctx, u, accessToken)
 
 	if err != nil {
 		return nil, err
@@ -171,7 +373,7 @@ func (t *TrueLayer) getAccounts(u *url.URL, accessToken string) ([]Account, erro
 	}
 
 	accountResp := AccountsResponse{}
-	err = json.NewDecoder(res.Body).Decode(&accountResp)
+	err = t.decodeJSON(res.Body, &accountResp)
 
 	if err != nil {
 		return nil, err
@@ -191,13 +393,28 @@ func (t *TrueLayer) getAccounts(u *url.URL, accessToken string) ([]Account, erro
 //   - the account
 //   - errors from the api request
 func (t *TrueLayer) GetAccount(accessToken string, accountID string) (*Account, error) {
+	return t.GetAccountWithContext(context.Background(), accessToken, accountID)
+}
+
+// GetAccountWithContext is the same as GetAccount but uses the provided context
+// for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the account from
+//   - accountID - the account ID to get
+//
+// returns
+//   - the account
+//   - errors from the api request
+func (t *TrueLayer) GetAccountWithContext(ctx context.Context, accessToken string, accountID string) (*Account, error) {
 	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1Account, accountID))
 
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := t.doAuthorizedGetRequest(u, accessToken)
+	res, err := t.doAuthorizedGetRequest(ctx, u, accessToken)
 
 	if err != nil {
 		return nil, err
@@ -210,15 +427,40 @@ func (t *TrueLayer) GetAccount(accessToken string, accountID string) (*Account,
 	}
 
 	accountResp := AccountsResponse{}
-	err = json.NewDecoder(res.Body).Decode(&accountResp)
+	err = t.decodeJSON(res.Body, &accountResp)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if len(accountResp.Results) == 0 {
+		return nil, ErrNoResults
+	}
+
 	return &accountResp.Results[0], nil
 }
 
+// GetAccountWithTokenSource is the same as GetAccountWithContext but takes the
+// access token from the provided TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//   - accountID - the account ID to get
+//
+// returns
+//   - the account
+//   - errors from the api request
+func (t *TrueLayer) GetAccountWithTokenSource(ctx context.Context, source *TokenSource, accountID string) (*Account, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetAccountWithContext(ctx, accessToken, accountID)
+}
+
 // GetAccountAsync triggers an async request to TrueLayer to get the specified
 // account based on the accountID, this account must be associated to the
 // provided accessToken or an error will occur.
@@ -232,7 +474,55 @@ func (t *TrueLayer) GetAccount(accessToken string, accountID string) (*Account,
 //   - truelayer response
 //   - errors from the api request
 func (t *TrueLayer) GetAccountAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
-	return t.doAsyncAccountRequest(fmt.Sprintf(EndpointDataV1Account, accountID), accessToken, webhookURI, nil)
+	return t.GetAccountAsyncWithContext(context.Background(), accessToken, webhookURI, accountID)
+}
+
+// GetAccountAsyncWithContext is the same as GetAccountAsync but uses the
+// provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - id of the account to get
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetAccountAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, fmt.Sprintf(EndpointDataV1Account, accountID), accessToken, webhookURI, nil)
+}
+
+// GetAccountAsyncRequest takes the result from a Webhook request and sends a
+// request to the correct endpoint to fetch the account.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountAsyncRequest(accessToken string, webhook *WebhookRequest) (*Account, error) {
+	return t.GetAccountAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetAccountAsyncRequestWithContext is the same as GetAccountAsyncRequest but
+// uses the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*Account, error) {
+	resp := AccountsResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Results) == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &resp.Results[0], nil
 }
 
 // GetAccountBalance retrieves the specified account's balance this account must
@@ -246,13 +536,28 @@ func (t *TrueLayer) GetAccountAsync(accessToken string, webhookURI string, accou
 //   - the balance
 //   - errors from the api request
 func (t *TrueLayer) GetAccountBalance(accessToken string, accountID string) (*AccountBalance, error) {
+	return t.GetAccountBalanceWithContext(context.Background(), accessToken, accountID)
+}
+
+// GetAccountBalanceWithContext is the same as GetAccountBalance but uses the
+// provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the account from
+//   - accountID - the account ID to get
+//
+// returns
+//   - the balance
+//   - errors from the api request
+func (t *TrueLayer) GetAccountBalanceWithContext(ctx context.Context, accessToken string, accountID string) (*AccountBalance, error) {
 	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1AccountBalance, accountID))
 
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := t.doAuthorizedGetRequest(u, accessToken)
+	res, err := t.doAuthorizedGetRequest(ctx, u, accessToken)
 
 	if err != nil {
 		return nil, err
@@ -265,15 +570,41 @@ func (t *TrueLayer) GetAccountBalance(accessToken string, accountID string) (*Ac
 	}
 
 	balanceResp := AccountBalanceResponse{}
-	err = json.NewDecoder(res.Body).Decode(&balanceResp)
+	err = t.decodeJSON(res.Body, &balanceResp)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if len(balanceResp.Results) == 0 {
+		return nil, ErrNoResults
+	}
+
 	return &balanceResp.Results[0], nil
 }
 
+// GetAccountBalanceWithTokenSource is the same as GetAccountBalanceWithContext
+// but takes the access token from the provided TokenSource, refreshing it if
+// required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//   - accountID - the account ID to get
+//
+// returns
+//   - the balance
+//   - errors from the api request
+func (t *TrueLayer) GetAccountBalanceWithTokenSource(ctx context.Context, source *TokenSource, accountID string) (*AccountBalance, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetAccountBalanceWithContext(ctx, accessToken, accountID)
+}
+
 // GetAccountBalanceAsync triggers an async request to TrueLayer to get the
 // specified account balance based on the accountID, this account must be
 // associated to the provided accessToken or an error will occur.
@@ -287,7 +618,56 @@ func (t *TrueLayer) GetAccountBalance(accessToken string, accountID string) (*Ac
 //   - truelayer response
 //   - errors from the api request
 func (t *TrueLayer) GetAccountBalanceAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
-	return t.doAsyncAccountRequest(fmt.Sprintf(EndpointDataV1AccountBalance, accountID), accessToken, webhookURI, nil)
+	return t.GetAccountBalanceAsyncWithContext(context.Background(), accessToken, webhookURI, accountID)
+}
+
+// GetAccountBalanceAsyncWithContext is the same as GetAccountBalanceAsync but
+// uses the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - id of the account to get
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetAccountBalanceAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, fmt.Sprintf(EndpointDataV1AccountBalance, accountID), accessToken, webhookURI, nil)
+}
+
+// GetAccountBalanceAsyncRequest takes the result from a Webhook request and
+// sends a request to the correct endpoint to fetch the balance.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountBalanceAsyncRequest(accessToken string, webhook *WebhookRequest) (*AccountBalance, error) {
+	return t.GetAccountBalanceAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetAccountBalanceAsyncRequestWithContext is the same as
+// GetAccountBalanceAsyncRequest but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountBalanceAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*AccountBalance, error) {
+	resp := AccountBalanceResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Results) == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &resp.Results[0], nil
 }
 
 // GetAccountTransactions retrieves the specified account's transactions this
@@ -302,13 +682,52 @@ func (t *TrueLayer) GetAccountBalanceAsync(accessToken string, webhookURI string
 //   - the transactions
 //   - errors from the api request
 func (t *TrueLayer) GetAccountTransactions(accessToken string, accountID string, opts *AccountOptions) ([]AccountTransaction, error) {
+	return t.GetAccountTransactionsWithContext(context.Background(), accessToken, accountID, opts)
+}
+
+// GetAccountTransactionsWithContext is the same as GetAccountTransactions but
+// uses the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the account from
+//   - accountID - the account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions
+//   - errors from the api request
+func (t *TrueLayer) GetAccountTransactionsWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) ([]AccountTransaction, error) {
 	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1AccountTransactions, accountID))
 
 	if err != nil {
 		return nil, err
 	}
 
-	return t.getAccountTransactions(u, accessToken, accountID, opts)
+	return t.getAccountTransactions(ctx, u, accessToken, accountID, opts)
+}
+
+// GetAccountTransactionsWithTokenSource is the same as
+// GetAccountTransactionsWithContext but takes the access token from the
+// provided TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//   - accountID - the account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions
+//   - errors from the api request
+func (t *TrueLayer) GetAccountTransactionsWithTokenSource(ctx context.Context, source *TokenSource, accountID string, opts *AccountOptions) ([]AccountTransaction, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetAccountTransactionsWithContext(ctx, accessToken, accountID, opts)
 }
 
 // GetAccountTransactionsAsync triggers an async request to TrueLayer to get the
@@ -325,7 +744,54 @@ func (t *TrueLayer) GetAccountTransactions(accessToken string, accountID string,
 //   - truelayer response
 //   - errors from the api request
 func (t *TrueLayer) GetAccountTransactionsAsync(accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error) {
-	return t.doAsyncAccountRequest(fmt.Sprintf(EndpointDataV1AccountTransactions, accountID), accessToken, webhookURI, opts)
+	return t.GetAccountTransactionsAsyncWithContext(context.Background(), accessToken, webhookURI, accountID, opts)
+}
+
+// GetAccountTransactionsAsyncWithContext is the same as
+// GetAccountTransactionsAsync but uses the provided context for the underlying
+// request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - id of the account to get
+//   - opts - options for the request
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetAccountTransactionsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, fmt.Sprintf(EndpointDataV1AccountTransactions, accountID), accessToken, webhookURI, opts)
+}
+
+// GetAccountTransactionsAsyncRequest takes the result from a Webhook request
+// and sends a request to the correct endpoint to fetch the transactions.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountTransactionsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]AccountTransaction, error) {
+	return t.GetAccountTransactionsAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetAccountTransactionsAsyncRequestWithContext is the same as
+// GetAccountTransactionsAsyncRequest but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountTransactionsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]AccountTransaction, error) {
+	resp := AccountTransactionsResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
 }
 
 // GetAccountPendingTransactions retrieves the specified account's pending
@@ -341,13 +807,53 @@ func (t *TrueLayer) GetAccountTransactionsAsync(accessToken string, webhookURI s
 //   - the transactions
 //   - errors from the api request
 func (t *TrueLayer) GetAccountPendingTransactions(accessToken string, accountID string, opts *AccountOptions) ([]AccountTransaction, error) {
+	return t.GetAccountPendingTransactionsWithContext(context.Background(), accessToken, accountID, opts)
+}
+
+// GetAccountPendingTransactionsWithContext is the same as
+// GetAccountPendingTransactions but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the account from
+//   - accountID - the account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions
+//   - errors from the api request
+func (t *TrueLayer) GetAccountPendingTransactionsWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) ([]AccountTransaction, error) {
 	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1AccountPendingTransactions, accountID))
 
 	if err != nil {
 		return nil, err
 	}
 
-	return t.getAccountTransactions(u, accessToken, accountID, opts)
+	return t.getAccountTransactions(ctx, u, accessToken, accountID, opts)
+}
+
+// GetAccountPendingTransactionsWithTokenSource is the same as
+// GetAccountPendingTransactionsWithContext but takes the access token from the
+// provided TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//   - accountID - the account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions
+//   - errors from the api request
+func (t *TrueLayer) GetAccountPendingTransactionsWithTokenSource(ctx context.Context, source *TokenSource, accountID string, opts *AccountOptions) ([]AccountTransaction, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetAccountPendingTransactionsWithContext(ctx, accessToken, accountID, opts)
 }
 
 // GetAccountPendingTransactionsAsync triggers an async request to TrueLayer to
@@ -365,13 +871,62 @@ func (t *TrueLayer) GetAccountPendingTransactions(accessToken string, accountID
 //   - truelayer response
 //   - errors from the api request
 func (t *TrueLayer) GetAccountPendingTransactionsAsync(accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error) {
-	return t.doAsyncAccountRequest(fmt.Sprintf(EndpointDataV1AccountPendingTransactions, accountID), accessToken, webhookURI, opts)
+	return t.GetAccountPendingTransactionsAsyncWithContext(context.Background(), accessToken, webhookURI, accountID, opts)
+}
+
+// GetAccountPendingTransactionsAsyncWithContext is the same as
+// GetAccountPendingTransactionsAsync but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - id of the account to get
+//   - opts - options for the request
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetAccountPendingTransactionsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, fmt.Sprintf(EndpointDataV1AccountPendingTransactions, accountID), accessToken, webhookURI, opts)
+}
+
+// GetAccountPendingTransactionsAsyncRequest takes the result from a Webhook
+// request and sends a request to the correct endpoint to fetch the
+// transactions.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountPendingTransactionsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]AccountTransaction, error) {
+	return t.GetAccountPendingTransactionsAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetAccountPendingTransactionsAsyncRequestWithContext is the same as
+// GetAccountPendingTransactionsAsyncRequest but uses the provided context for
+// the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountPendingTransactionsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]AccountTransaction, error) {
+	resp := AccountTransactionsResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
 }
 
 // getAccountTransactions retrieves the specified account's transactions either
 // pending or not depending on the passed URL.
 //
 // params
+//   - ctx - context for the request
 //   - url - the url to request
 //     (EndpointDataV1AccountTransactions|EndpointDataV1AccountPendingTransactions)
 //   - accessToken - access token to get the account from
@@ -381,7 +936,39 @@ func (t *TrueLayer) GetAccountPendingTransactionsAsync(accessToken string, webho
 // returns
 //   - the transactions
 //   - errors from the api request
-func (t *TrueLayer) getAccountTransactions(url *url.URL, accessToken string, accountID string, opts *AccountOptions) ([]AccountTransaction, error) {
+func (t *TrueLayer) getAccountTransactions(ctx context.Context, url *url.URL, accessToken string, accountID string, opts *AccountOptions) ([]AccountTransaction, error) {
+	res, err := t.doAccountTransactionsRequest(ctx, url, accessToken, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	transactionsResp := AccountTransactionsResponse{}
+	err = t.decodeJSON(res.Body, &transactionsResp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transactionsResp.Results, nil
+}
+
+// doAccountTransactionsRequest requests the transactions at the provided url,
+// the caller must close the response body.
+//
+// params
+//   - ctx - context for the request
+//   - url - the url to request
+//     (EndpointDataV1AccountTransactions|EndpointDataV1AccountPendingTransactions)
+//   - accessToken - access token to get the account from
+//   - opts - options for the request
+//
+// returns
+//   - the http response
+//   - errors from the api request
+func (t *TrueLayer) doAccountTransactionsRequest(ctx context.Context, url *url.URL, accessToken string, opts *AccountOptions) (*http.Response, error) {
 	if opts != nil {
 		if opts.From == nil || opts.To == nil {
 			return nil, ErrToFromNil
@@ -393,26 +980,18 @@ func (t *TrueLayer) getAccountTransactions(url *url.URL, accessToken string, acc
 		url.RawQuery = q.Encode()
 	}
 
-	res, err := t.doAuthorizedGetRequest(url, accessToken)
+	res, err := t.doAuthorizedGetRequest(ctx, url, accessToken)
 
 	if err != nil {
 		return nil, err
 	}
 
-	defer res.Body.Close()
-
 	if res.StatusCode >= 300 {
+		defer res.Body.Close()
 		return nil, parseErrorResponse(res)
 	}
 
-	transactionsResp := AccountTransactionsResponse{}
-	err = json.NewDecoder(res.Body).Decode(&transactionsResp)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return transactionsResp.Results, nil
+	return res, nil
 }
 
 // GetAccountStandingOrders retrieves the specified account's standing orders
@@ -427,13 +1006,28 @@ func (t *TrueLayer) getAccountTransactions(url *url.URL, accessToken string, acc
 //   - the standing orders
 //   - errors from the api request
 func (t *TrueLayer) GetAccountStandingOrders(accessToken string, accountID string) ([]AccountStandingOrder, error) {
+	return t.GetAccountStandingOrdersWithContext(context.Background(), accessToken, accountID)
+}
+
+// GetAccountStandingOrdersWithContext is the same as GetAccountStandingOrders
+// but uses the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the account from
+//   - accountID - the account ID to get
+//
+// returns
+//   - the standing orders
+//   - errors from the api request
+func (t *TrueLayer) GetAccountStandingOrdersWithContext(ctx context.Context, accessToken string, accountID string) ([]AccountStandingOrder, error) {
 	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1AccountStandingOrders, accountID))
 
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := t.doAuthorizedGetRequest(u, accessToken)
+	res, err := t.doAuthorizedGetRequest(ctx, u, accessToken)
 
 	if err != nil {
 		return nil, err
@@ -446,7 +1040,7 @@ func (t *TrueLayer) GetAccountStandingOrders(accessToken string, accountID strin
 	}
 
 	standingOrderResp := AccountStandingOrderResponse{}
-	err = json.NewDecoder(res.Body).Decode(&standingOrderResp)
+	err = t.decodeJSON(res.Body, &standingOrderResp)
 
 	if err != nil {
 		return nil, err
@@ -455,6 +1049,28 @@ func (t *TrueLayer) GetAccountStandingOrders(accessToken string, accountID strin
 	return standingOrderResp.Results, nil
 }
 
+// GetAccountStandingOrdersWithTokenSource is the same as
+// GetAccountStandingOrdersWithContext but takes the access token from the
+// provided TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//   - accountID - the account ID to get
+//
+// returns
+//   - the standing orders
+//   - errors from the api request
+func (t *TrueLayer) GetAccountStandingOrdersWithTokenSource(ctx context.Context, source *TokenSource, accountID string) ([]AccountStandingOrder, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetAccountStandingOrdersWithContext(ctx, accessToken, accountID)
+}
+
 // GetAccountStandingOrdersAsync triggers an async request to TrueLayer to get
 // the specified account's standing orders based on the accountID, this account
 // must be associated to the provided accessToken or an error will occur.
@@ -468,11 +1084,57 @@ func (t *TrueLayer) GetAccountStandingOrders(accessToken string, accountID strin
 //   - truelayer response
 //   - errors from the api request
 func (t *TrueLayer) GetAccountStandingOrdersAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
-	return t.doAsyncAccountRequest(fmt.Sprintf(EndpointDataV1AccountStandingOrders, accountID), accessToken, webhookURI, nil)
+	return t.GetAccountStandingOrdersAsyncWithContext(context.Background(), accessToken, webhookURI, accountID)
+}
+
+// GetAccountStandingOrdersAsyncWithContext is the same as
+// GetAccountStandingOrdersAsync but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - id of the account to get
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetAccountStandingOrdersAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, fmt.Sprintf(EndpointDataV1AccountStandingOrders, accountID), accessToken, webhookURI, nil)
+}
+
+// GetAccountStandingOrdersAsyncRequest takes the result from a Webhook request
+// and sends a request to the correct endpoint to fetch the standing orders.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountStandingOrdersAsyncRequest(accessToken string, webhook *WebhookRequest) ([]AccountStandingOrder, error) {
+	return t.GetAccountStandingOrdersAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetAccountStandingOrdersAsyncRequestWithContext is the same as
+// GetAccountStandingOrdersAsyncRequest but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountStandingOrdersAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]AccountStandingOrder, error) {
+	resp := AccountStandingOrderResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
 }
 
 // GetAccountDirectDebits retrieves the specified account's direct debits this
-//account must be associated to the provided accessToken or an error will occur.
+// account must be associated to the provided accessToken or an error will occur.
 //
 // params
 //   - accessToken - access token to get the account from
@@ -482,13 +1144,28 @@ func (t *TrueLayer) GetAccountStandingOrdersAsync(accessToken string, webhookURI
 //   - the direct debits
 //   - errors from the api request
 func (t *TrueLayer) GetAccountDirectDebits(accessToken string, accountID string) ([]AccountDirectDebit, error) {
+	return t.GetAccountDirectDebitsWithContext(context.Background(), accessToken, accountID)
+}
+
+// GetAccountDirectDebitsWithContext is the same as GetAccountDirectDebits but
+// uses the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the account from
+//   - accountID - the account ID to get
+//
+// returns
+//   - the direct debits
+//   - errors from the api request
+func (t *TrueLayer) GetAccountDirectDebitsWithContext(ctx context.Context, accessToken string, accountID string) ([]AccountDirectDebit, error) {
 	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1AccountDirectDebits, accountID))
 
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := t.doAuthorizedGetRequest(u, accessToken)
+	res, err := t.doAuthorizedGetRequest(ctx, u, accessToken)
 
 	if err != nil {
 		return nil, err
@@ -501,7 +1178,7 @@ func (t *TrueLayer) GetAccountDirectDebits(accessToken string, accountID string)
 	}
 
 	directDebitResp := AccountDirectDebitResponse{}
-	err = json.NewDecoder(res.Body).Decode(&directDebitResp)
+	err = t.decodeJSON(res.Body, &directDebitResp)
 
 	if err != nil {
 		return nil, err
@@ -510,6 +1187,28 @@ func (t *TrueLayer) GetAccountDirectDebits(accessToken string, accountID string)
 	return directDebitResp.Results, nil
 }
 
+// GetAccountDirectDebitsWithTokenSource is the same as
+// GetAccountDirectDebitsWithContext but takes the access token from the
+// provided TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//   - accountID - the account ID to get
+//
+// returns
+//   - the direct debits
+//   - errors from the api request
+func (t *TrueLayer) GetAccountDirectDebitsWithTokenSource(ctx context.Context, source *TokenSource, accountID string) ([]AccountDirectDebit, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetAccountDirectDebitsWithContext(ctx, accessToken, accountID)
+}
+
 // GetAccountDirectDebitsAsync triggers an async request to TrueLayer to get the
 // specified account's direct debits based on the accountID, this account must
 // be associated to the provided accessToken or an error will occur.
@@ -523,13 +1222,60 @@ func (t *TrueLayer) GetAccountDirectDebits(accessToken string, accountID string)
 //   - truelayer response
 //   - errors from the api request
 func (t *TrueLayer) GetAccountDirectDebitsAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
-	return t.doAsyncAccountRequest(fmt.Sprintf(EndpointDataV1AccountStandingOrders, accountID), accessToken, webhookURI, nil)
+	return t.GetAccountDirectDebitsAsyncWithContext(context.Background(), accessToken, webhookURI, accountID)
+}
+
+// GetAccountDirectDebitsAsyncWithContext is the same as
+// GetAccountDirectDebitsAsync but uses the provided context for the underlying
+// request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - id of the account to get
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetAccountDirectDebitsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, fmt.Sprintf(EndpointDataV1AccountDirectDebits, accountID), accessToken, webhookURI, nil)
+}
+
+// GetAccountDirectDebitsAsyncRequest takes the result from a Webhook request
+// and sends a request to the correct endpoint to fetch the direct debits.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountDirectDebitsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]AccountDirectDebit, error) {
+	return t.GetAccountDirectDebitsAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetAccountDirectDebitsAsyncRequestWithContext is the same as
+// GetAccountDirectDebitsAsyncRequest but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetAccountDirectDebitsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]AccountDirectDebit, error) {
+	resp := AccountDirectDebitResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
 }
 
 // doAsyncAccountRequest starts the process of getting account information
 // through TrueLayer's async function.
 //
 // params
+//   - ctx - context for the request
 //   - endpoint - api endpoint to access
 //   - accessToken - access token to get the info from
 //   - webhookURI - uri to access upon async job completion (optional)
@@ -538,7 +1284,7 @@ func (t *TrueLayer) GetAccountDirectDebitsAsync(accessToken string, webhookURI s
 // returns
 //   - truelayer response
 //   - errors from the api request
-func (t *TrueLayer) doAsyncAccountRequest(endpoint string, accessToken string, webhookURI string, opts *AccountOptions) (*AsyncRequestResponse, error) {
+func (t *TrueLayer) doAsyncAccountRequest(ctx context.Context, endpoint string, accessToken string, webhookURI string, opts *AccountOptions) (*AsyncRequestResponse, error) {
 	u, err := buildURL(t.getBaseURL(), endpoint)
 
 	if err != nil {
@@ -560,7 +1306,7 @@ func (t *TrueLayer) doAsyncAccountRequest(endpoint string, accessToken string, w
 
 	u.RawQuery = q.Encode()
 
-	res, err := t.doAuthorizedGetRequest(u, accessToken)
+	res, err := t.doAuthorizedGetRequest(ctx, u, accessToken)
 
 	if err != nil {
 		return nil, err
@@ -1,21 +1,68 @@
 package truelayer
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
 )
 
 const (
-	authBaseURLSandbox = "https://auth.truelayer-sandbox.com"
-	authBaseURL        = "https://auth.truelayer.com"
 	authTokenEndpoint  = "/connect/token"
+	authDeleteEndpoint = "/api/delete"
+
+	codeChallengeMethodS256 = "S256"
+
+	// clientTokenRenewWindow is how long before expiry a cached client
+	// credentials token is renewed.
+	clientTokenRenewWindow = time.Minute
+
+	ErrStateMismatch        = StrError("state does not match the expected state")
+	ErrInsecureCodeExchange = StrError("code exchange requires a code verifier or expected state unless insecure is set")
+	ErrConnectionRevoked    = StrError("connection has already been revoked")
 )
 
+// AuthenticationLink is an authentication link along with the state and PKCE
+// code verifier used to generate it. The State and CodeVerifier must be kept
+// by the caller, e.g. in the user's session, until the code is exchanged.
+type AuthenticationLink struct {
+	URL          string
+	State        string
+	CodeVerifier string
+}
+
+// AccessTokenOptions are the options used when exchanging an authentication
+// code for an access token. At least one of CodeVerifier or ExpectedState is
+// required unless Insecure is set.
+type AccessTokenOptions struct {
+	// CodeVerifier is the PKCE verifier used to generate the link.
+	CodeVerifier string
+	// State is the state returned to the redirect URI.
+	State string
+	// ExpectedState is the state the link was generated with, State must
+	// match it before the code is exchanged.
+	ExpectedState string
+	// Insecure allows the code to be exchanged without a code verifier or
+	// state check, e.g. for links generated with GetAuthenticationLink.
+	Insecure bool
+}
+
 // GetAuthenticationLink generates a link that can be used to authenticate
 // against multiple providers with a specific scope of permissions.
 //
+// The link has no state or PKCE challenge so the code can only be exchanged
+// with AccessTokenOptions.Insecure set, GetSecureAuthenticationLink should be
+// preferred.
+//
 // params
 //   - providers - the allowed authentication providers
 //   - permissions - the scope of permissions you want
@@ -24,29 +71,52 @@ const (
 //
 // returns
 //   - link - the authentication link
-//   - err - error parsing the base URL - should not occur
+//   - err - invalid arguments or error parsing the base URL
 func (t *TrueLayer) GetAuthenticationLink(providers []string, permissions []string, redirURI *url.URL, postCode bool) (link string, err error) {
-	u, err := buildURL(t.getAuthBaseURL(), "")
-
-	if err != nil {
-		return link, err
-	}
-
-	q := t.getURLValuesWithClientInfo(u.Query(), false)
-	q.Add("response_type", "code")
+	return t.GetAuthenticationLinkWithOptions(newAuthLinkOptions(providers, permissions, redirURI, postCode))
+}
 
-	q.Add("scope", strings.Join(permissions, " "))
-	q.Add("providers", strings.Join(providers, " "))
+// GetSecureAuthenticationLink is the same as GetAuthenticationLink but also
+// generates a cryptographically random state and a PKCE (S256) code verifier
+// and challenge. The returned State and CodeVerifier must be passed to
+// GetAccessToken using AccessTokenOptions.
+//
+// params
+//   - providers - the allowed authentication providers
+//   - permissions - the scope of permissions you want
+//   - redirectURI - where to redirect the request to
+//   - postCode - submit the code using `POST` over `GET`
+//
+// returns
+//   - the authentication link, state and code verifier
+//   - errors generating the state or verifier
+func (t *TrueLayer) GetSecureAuthenticationLink(providers []string, permissions []string, redirURI *url.URL, postCode bool) (*AuthenticationLink, error) {
+	return t.GetSecureAuthenticationLinkWithOptions(newAuthLinkOptions(providers, permissions, redirURI, postCode))
+}
 
-	q.Add("redirect_uri", redirURI.String())
+// newAuthLinkOptions converts the GetAuthenticationLink arguments into
+// AuthLinkOptions.
+//
+// params
+//   - providers - the allowed authentication providers
+//   - permissions - the scope of permissions you want
+//   - redirectURI - where to redirect the request to
+//   - postCode - submit the code using `POST` over `GET`
+//
+// returns
+//   - the auth link options
+func newAuthLinkOptions(providers []string, permissions []string, redirURI *url.URL, postCode bool) *AuthLinkOptions {
+	opts := &AuthLinkOptions{
+		Providers:   providers,
+		Permissions: permissions,
+		RedirectURI: redirURI,
+	}
 
 	if postCode {
-		q.Add("response_mode", "form_post")
+		opts.ResponseMode = ResponseModeFormPost
 	}
 
-	u.RawQuery = q.Encode()
-
-	return u.String(), err
+	return opts
 }
 
 // GetAccessToken contacts the TrueLayer API and gets an access token to allow
@@ -54,17 +124,55 @@ func (t *TrueLayer) GetAuthenticationLink(providers []string, permissions []stri
 //
 // params
 //   - code - authentication code retrieved from the user
+//   - redirURI - the redirect URI the link was generated with
+//   - opts - PKCE and state options
 //
 // returns
 //   - token - access token
-//   - err - any errors that have occurred including API errors
-func (t *TrueLayer) GetAccessToken(code string, redirURI *url.URL) (token *AccessTokenResponse, err error) {
+//   - err - ErrRedirectURINil, ErrInsecureCodeExchange or ErrStateMismatch
+//     when the arguments do not pass, any other errors that have occurred
+//     including API errors
+func (t *TrueLayer) GetAccessToken(code string, redirURI *url.URL, opts *AccessTokenOptions) (token *AccessTokenResponse, err error) {
+	return t.GetAccessTokenWithContext(context.Background(), code, redirURI, opts)
+}
+
+// GetAccessTokenWithContext is the same as GetAccessToken but uses the provided
+// context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - code - authentication code retrieved from the user
+//   - redirURI - the redirect URI the link was generated with
+//   - opts - PKCE and state options
+//
+// returns
+//   - token - access token
+//   - err - ErrRedirectURINil, ErrInsecureCodeExchange or ErrStateMismatch
+//     when the arguments do not pass, any other errors that have occurred
+//     including API errors
+func (t *TrueLayer) GetAccessTokenWithContext(ctx context.Context, code string, redirURI *url.URL, opts *AccessTokenOptions) (token *AccessTokenResponse, err error) {
+	if redirURI == nil {
+		return nil, ErrRedirectURINil
+	}
+
+	if opts == nil || (opts.CodeVerifier == "" && opts.ExpectedState == "" && !opts.Insecure) {
+		return nil, ErrInsecureCodeExchange
+	}
+
+	if opts.ExpectedState != "" && subtle.ConstantTimeCompare([]byte(opts.ExpectedState), []byte(opts.State)) != 1 {
+		return nil, ErrStateMismatch
+	}
+
 	body := t.getNewURLValuesWithClientInfo(true)
 	body.Add("grant_type", "authorization_code")
 	body.Add("redirect_uri", redirURI.String())
 	body.Add("code", code)
 
-	return t.authDoTokenRequest(body)
+	if opts.CodeVerifier != "" {
+		body.Add("code_verifier", opts.CodeVerifier)
+	}
+
+	return t.authDoTokenRequest(ctx, body)
 }
 
 // RefreshAccessToken takes a refresh token and returns a refreshed access token
@@ -77,30 +185,277 @@ func (t *TrueLayer) GetAccessToken(code string, redirURI *url.URL) (token *Acces
 //   - token - access token
 //   - err - any errors that have occurred including API errors
 func (t *TrueLayer) RefreshAccessToken(refreshToken string) (token *AccessTokenResponse, err error) {
+	return t.RefreshAccessTokenWithContext(context.Background(), refreshToken)
+}
+
+// RefreshAccessTokenWithContext is the same as RefreshAccessToken but uses the
+// provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - refreshToken - user refresh token
+//
+// returns
+//   - token - access token
+//   - err - any errors that have occurred including API errors
+func (t *TrueLayer) RefreshAccessTokenWithContext(ctx context.Context, refreshToken string) (token *AccessTokenResponse, err error) {
 	body := t.getNewURLValuesWithClientInfo(true)
 	body.Add("grant_type", "refresh_token")
 	body.Add("refresh_token", refreshToken)
 
-	return t.authDoTokenRequest(body)
+	return t.authDoTokenRequest(ctx, body)
+}
+
+// GetClientCredentialsToken gets an access token using the client credentials
+// grant, this token is not associated to a user and is used for server to
+// server APIs. Tokens are cached per set of scopes and renewed shortly before
+// they expire, concurrent callers for the same scopes share a single request.
+//
+// params
+//   - scopes - the scopes requested for the token
+//
+// returns
+//   - token - the client access token
+//   - err - any errors that have occurred including API errors
+func (t *TrueLayer) GetClientCredentialsToken(scopes []string) (token *Token, err error) {
+	return t.GetClientCredentialsTokenWithContext(context.Background(), scopes)
+}
+
+// GetClientCredentialsTokenWithContext is the same as GetClientCredentialsToken
+// but uses the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - scopes - the scopes requested for the token
+//
+// returns
+//   - token - the client access token
+//   - err - any errors that have occurred including API errors
+func (t *TrueLayer) GetClientCredentialsTokenWithContext(ctx context.Context, scopes []string) (token *Token, err error) {
+	sorted := append([]string{}, scopes...)
+	sort.Strings(sorted)
+	scope := strings.Join(sorted, " ")
+
+	for {
+		t.clientTokensMu.Lock()
+
+		if token, ok := t.clientTokens[scope]; ok && time.Now().Add(clientTokenRenewWindow).Before(token.Expiry) {
+			t.clientTokensMu.Unlock()
+
+			cached := *token
+			return &cached, nil
+		}
+
+		fetch, ok := t.clientTokenFetches[scope]
+
+		if !ok {
+			fetch = &clientTokenFetch{done: make(chan struct{})}
+
+			if t.clientTokenFetches == nil {
+				t.clientTokenFetches = map[string]*clientTokenFetch{}
+			}
+
+			t.clientTokenFetches[scope] = fetch
+		}
+
+		t.clientTokensMu.Unlock()
+
+		if !ok {
+			return t.fetchClientCredentialsToken(ctx, scope, fetch)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-fetch.done:
+		}
+
+		// the fetch was abandoned by its caller, try again with this context
+		if errors.Is(fetch.err, context.Canceled) || errors.Is(fetch.err, context.DeadlineExceeded) {
+			continue
+		}
+
+		if fetch.err != nil {
+			return nil, fetch.err
+		}
+
+		fetched := *fetch.token
+		return &fetched, nil
+	}
+}
+
+// clientTokenFetch is an in flight client credentials token request which
+// other callers for the same scope wait on.
+type clientTokenFetch struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+// fetchClientCredentialsToken requests a client credentials token, caching it
+// and completing the fetch for any waiting callers.
+//
+// params
+//   - ctx - context for the request
+//   - scope - the space separated scopes
+//   - fetch - the in flight fetch to complete
+//
+// returns
+//   - token - the client access token
+//   - err - any errors that have occurred including API errors
+func (t *TrueLayer) fetchClientCredentialsToken(ctx context.Context, scope string, fetch *clientTokenFetch) (*Token, error) {
+	body := t.getNewURLValuesWithClientInfo(true)
+	body.Add("grant_type", "client_credentials")
+	body.Add("scope", scope)
+
+	res, err := t.authDoTokenRequest(ctx, body)
+
+	if err == nil {
+		fetch.token = NewToken(res)
+	}
+
+	fetch.err = err
+
+	t.clientTokensMu.Lock()
+
+	if err == nil {
+		if t.clientTokens == nil {
+			t.clientTokens = map[string]*Token{}
+		}
+
+		t.clientTokens[scope] = fetch.token
+	}
+
+	delete(t.clientTokenFetches, scope)
+	t.clientTokensMu.Unlock()
+
+	close(fetch.done)
+
+	if err != nil {
+		return nil, err
+	}
+
+	token := *fetch.token
+	return &token, nil
+}
+
+// DeleteConnection revokes the connection associated to the access token,
+// TrueLayer will no longer be able to access the user's bank data and both the
+// access and refresh tokens are invalidated.
+//
+// The access token must not have expired, an expired token and an already
+// revoked connection both fail with `401 Unauthorized` and can't be told
+// apart. Use DeleteConnectionWithTokenSource to find out whether the
+// connection has already been revoked.
+//
+// params
+//   - accessToken - access token of the connection to delete
+//
+// returns
+//   - *ErrorResponse matching ErrUnauthorized if the access token has
+//     expired or the connection has already been revoked
+//   - any other errors that have occurred including API errors
+func (t *TrueLayer) DeleteConnection(accessToken string) error {
+	return t.DeleteConnectionWithContext(context.Background(), accessToken)
+}
+
+// DeleteConnectionWithContext is the same as DeleteConnection but uses the
+// provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token of the connection to delete
+//
+// returns
+//   - *ErrorResponse matching ErrUnauthorized if the access token has
+//     expired or the connection has already been revoked
+//   - any other errors that have occurred including API errors
+func (t *TrueLayer) DeleteConnectionWithContext(ctx context.Context, accessToken string) error {
+	u, err := buildURL(t.getAuthBaseURL(), authDeleteEndpoint)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := t.doAuthorizedRequest(ctx, http.MethodDelete, u, accessToken, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return parseErrorResponse(res)
+	}
+
+	return nil
+}
+
+// DeleteConnectionWithTokenSource is the same as DeleteConnectionWithContext
+// but takes the access token from the provided TokenSource. When the delete is
+// unauthorized the token is refreshed and the delete retried, so an expired
+// access token is not mistaken for a revoked connection.
+//
+// params
+//   - ctx - context for the store and requests
+//   - source - token source of the connection to delete
+//
+// returns
+//   - ErrConnectionRevoked if the connection has already been revoked, shown
+//     by the refresh token no longer being valid or a freshly refreshed access
+//     token being unauthorized
+//   - any other errors that have occurred including API errors
+func (t *TrueLayer) DeleteConnectionWithTokenSource(ctx context.Context, source *TokenSource) error {
+	token, err := source.Token(ctx)
+
+	if errors.Is(err, ErrInvalidGrant) {
+		return ErrConnectionRevoked
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = t.DeleteConnectionWithContext(ctx, token.AccessToken)
+
+	if !errors.Is(err, ErrUnauthorized) {
+		return err
+	}
+
+	token, err = source.forceRefresh(ctx, token)
+
+	if err != nil {
+		return err
+	}
+
+	err = t.DeleteConnectionWithContext(ctx, token.AccessToken)
+
+	if errors.Is(err, ErrUnauthorized) {
+		return ErrConnectionRevoked
+	}
+
+	return err
 }
 
 // authDoTokenRequest builds and executes authentication requests for the
 // TrueLayer api.
 //
 // params
+//   - ctx - context for the request
 //   - body - request payload
 //
 // returns
 //   - token - access token
 //   - err - any errors that have occurred including API errors
-func (t *TrueLayer) authDoTokenRequest(body url.Values) (token *AccessTokenResponse, err error) {
+func (t *TrueLayer) authDoTokenRequest(ctx context.Context, body url.Values) (token *AccessTokenResponse, err error) {
 	u, err := buildURL(t.getAuthBaseURL(), authTokenEndpoint)
 
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := t.doRequestWithFormURLEncodedBody(http.MethodPost, u.String(), body)
+	res, err := t.doRequestWithFormURLEncodedBody(ctx, http.MethodPost, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +476,7 @@ func (t *TrueLayer) authDoTokenRequest(body url.Values) (token *AccessTokenRespo
 // in authentication requests.
 //
 // params
+//   - ctx - context for the request
 //   - method - http request method
 //   - url - url to request
 //   - body - url.Values to be encoded for the request body
@@ -128,8 +484,8 @@ func (t *TrueLayer) authDoTokenRequest(body url.Values) (token *AccessTokenRespo
 // returns
 //   - response from http request
 //   - any errors from creating the requests or executing the request
-func (t *TrueLayer) doRequestWithFormURLEncodedBody(method, url string, body url.Values) (*http.Response, error) {
-	req, err := http.NewRequest(method, url, strings.NewReader(body.Encode()))
+func (t *TrueLayer) doRequestWithFormURLEncodedBody(ctx context.Context, method, url string, body url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body.Encode()))
 
 	if err != nil {
 		return nil, err
@@ -137,7 +493,37 @@ func (t *TrueLayer) doRequestWithFormURLEncodedBody(method, url string, body url
 
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	return t.httpClient.Do(req)
+	return t.do(req)
+}
+
+// doRequestWithJSONBody creates a HTTP request object with the payload encoded
+// as JSON and the Content-Type header set to `application/json`.
+//
+// params
+//   - ctx - context for the request
+//   - method - http request method
+//   - url - url to request
+//   - payload - value to be JSON encoded for the request body
+//
+// returns
+//   - response from http request
+//   - any errors from creating the requests or executing the request
+func (t *TrueLayer) doRequestWithJSONBody(ctx context.Context, method, url string, payload interface{}) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	return t.do(req)
 }
 
 // getNewURLValuesWithClientInfo creates a new url.Values object and injects it
@@ -171,16 +557,40 @@ func (t *TrueLayer) getURLValuesWithClientInfo(values url.Values, withSecret boo
 	return values
 }
 
-// getAuthBaseURL parses the baseAuthURL for either the sandbox or non-sandbox
-// TrueLayer environments and returns them. Using a utility method to reduce
-// code duplication.
+// getAuthBaseURL returns the auth base URL for the client's environment. Using
+// a utility method to reduce code duplication.
 //
 // returns
 //   - the base url
 func (t *TrueLayer) getAuthBaseURL() string {
-	if t.sandbox {
-		return authBaseURLSandbox
+	return t.environment.AuthURL
+}
+
+// generateRandomString generates a cryptographically random URL safe string
+// suitable for use as a state or PKCE code verifier.
+//
+// returns
+//   - the random string
+//   - errors reading random bytes
+func generateRandomString() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+
+	if err != nil {
+		return "", err
 	}
 
-	return authBaseURL
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// generateCodeChallenge generates the S256 PKCE code challenge for a verifier.
+//
+// params
+//   - verifier - the PKCE code verifier
+//
+// returns
+//   - the code challenge
+func generateCodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
 }
@@ -0,0 +1,208 @@
+package truelayer
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	ResponseModeQuery    = "query"
+	ResponseModeFragment = "fragment"
+	ResponseModeFormPost = "form_post"
+
+	ErrAuthLinkOptionsNil  = StrError("auth link options must not be nil")
+	ErrRedirectURINil      = StrError("redirect uri must not be nil")
+	ErrPermissionsEmpty    = StrError("at least one permission is required")
+	ErrProvidersEmpty      = StrError("at least one provider or a provider id is required")
+	ErrResponseModeInvalid = StrError("response mode must be query, fragment or form_post")
+	ErrProviderDisabled    = StrError("provider id must not be a disabled provider")
+	ErrLanguageInvalid     = StrError("language must be a two letter ISO 639-1 code")
+)
+
+// AuthLinkOptions are all of the options supported by the TrueLayer auth
+// dialog. Only Permissions, RedirectURI and either Providers or ProviderID
+// are required.
+type AuthLinkOptions struct {
+	// Providers are the allowed authentication providers.
+	Providers []string
+	// Permissions are the scope of permissions requested.
+	Permissions []string
+	// RedirectURI is where the user is redirected to after authenticating.
+	RedirectURI *url.URL
+	// ResponseMode is how the code is returned, one of ResponseModeQuery,
+	// ResponseModeFragment or ResponseModeFormPost. Defaults to query.
+	ResponseMode string
+	// ProviderID pre-selects a provider, skipping the provider selection
+	// screen.
+	ProviderID string
+	// DisabledProviders are providers hidden from the provider selection.
+	DisabledProviders []string
+	// Countries filters the provider selection to the ISO 3166-1 alpha-2
+	// country codes.
+	Countries []string
+	// Language is the ISO 639-1 code of the language to display the dialog in.
+	Language string
+	// TrackingID is an id used to track the user through the dialog.
+	TrackingID string
+	// Nonce is returned in the ID token.
+	Nonce string
+	// ConsentID is the id of a previously captured consent.
+	ConsentID string
+	// State is returned to the redirect URI.
+	State string
+	// CodeChallenge is the S256 PKCE code challenge.
+	CodeChallenge string
+}
+
+// Validate checks that the required options are set and that the options
+// can be used together.
+//
+// returns
+//   - the first invalid option error
+func (o *AuthLinkOptions) Validate() error {
+	if o.RedirectURI == nil {
+		return ErrRedirectURINil
+	}
+
+	if len(o.Permissions) == 0 {
+		return ErrPermissionsEmpty
+	}
+
+	if len(o.Providers) == 0 && o.ProviderID == "" {
+		return ErrProvidersEmpty
+	}
+
+	switch o.ResponseMode {
+	case "", ResponseModeQuery, ResponseModeFragment, ResponseModeFormPost:
+	default:
+		return ErrResponseModeInvalid
+	}
+
+	for _, provider := range o.DisabledProviders {
+		if provider == o.ProviderID {
+			return ErrProviderDisabled
+		}
+	}
+
+	if o.Language != "" && len(o.Language) != 2 {
+		return ErrLanguageInvalid
+	}
+
+	return nil
+}
+
+// GetAuthenticationLinkWithOptions generates a link that can be used to
+// authenticate using the provided options.
+//
+// params
+//   - opts - the auth link options
+//
+// returns
+//   - link - the authentication link
+//   - err - invalid options or error parsing the base URL
+func (t *TrueLayer) GetAuthenticationLinkWithOptions(opts *AuthLinkOptions) (link string, err error) {
+	if opts == nil {
+		return link, ErrAuthLinkOptionsNil
+	}
+
+	err = opts.Validate()
+
+	if err != nil {
+		return link, err
+	}
+
+	u, err := buildURL(t.getAuthBaseURL(), "")
+
+	if err != nil {
+		return link, err
+	}
+
+	q := t.getURLValuesWithClientInfo(u.Query(), false)
+	q.Add("response_type", "code")
+
+	q.Add("scope", strings.Join(opts.Permissions, " "))
+
+	if len(opts.Providers) > 0 {
+		q.Add("providers", strings.Join(opts.Providers, " "))
+	}
+
+	q.Add("redirect_uri", opts.RedirectURI.String())
+
+	addQueryIfSet(q, "response_mode", opts.ResponseMode)
+	addQueryIfSet(q, "provider_id", opts.ProviderID)
+	addQueryIfSet(q, "disable_providers", strings.Join(opts.DisabledProviders, " "))
+	addQueryIfSet(q, "countries", strings.Join(opts.Countries, ","))
+	addQueryIfSet(q, "language", opts.Language)
+	addQueryIfSet(q, "tracking_id", opts.TrackingID)
+	addQueryIfSet(q, "nonce", opts.Nonce)
+	addQueryIfSet(q, "consent_id", opts.ConsentID)
+	addQueryIfSet(q, "state", opts.State)
+
+	if opts.CodeChallenge != "" {
+		q.Add("code_challenge", opts.CodeChallenge)
+		q.Add("code_challenge_method", codeChallengeMethodS256)
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// GetSecureAuthenticationLinkWithOptions is the same as
+// GetAuthenticationLinkWithOptions but generates a cryptographically random
+// state and a PKCE (S256) code verifier and challenge, overriding any State and
+// CodeChallenge options. The returned State and CodeVerifier must be passed to
+// GetAccessToken using AccessTokenOptions.
+//
+// params
+//   - opts - the auth link options
+//
+// returns
+//   - the authentication link, state and code verifier
+//   - invalid options or errors generating the state or verifier
+func (t *TrueLayer) GetSecureAuthenticationLinkWithOptions(opts *AuthLinkOptions) (*AuthenticationLink, error) {
+	if opts == nil {
+		return nil, ErrAuthLinkOptionsNil
+	}
+
+	state, err := generateRandomString()
+
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := generateRandomString()
+
+	if err != nil {
+		return nil, err
+	}
+
+	secureOpts := *opts
+	secureOpts.State = state
+	secureOpts.CodeChallenge = generateCodeChallenge(verifier)
+
+	link, err := t.GetAuthenticationLinkWithOptions(&secureOpts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthenticationLink{
+		URL:          link,
+		State:        state,
+		CodeVerifier: verifier,
+	}, nil
+}
+
+// addQueryIfSet adds the key and value to the query if the value is not
+// empty.
+//
+// params
+//   - q - the query to add to
+//   - key - the query key
+//   - value - the query value
+func addQueryIfSet(q url.Values, key string, value string) {
+	if value != "" {
+		q.Add(key, value)
+	}
+}
@@ -0,0 +1,251 @@
+package truelayer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	EndpointDataV1Batch = "/data/v1/batch"
+)
+
+// Batch is a builder used to request multiple pieces of account data for a
+// single set of credentials in one call to the TrueLayer batch endpoint. A
+// Batch is created using TrueLayer.NewBatch and is always executed
+// asynchronously.
+type Batch struct {
+	t       *TrueLayer
+	request batchRequest
+	opts    *AccountOptions
+}
+
+type batchRequest struct {
+	Accounts batchAccountsRequest `json:"accounts"`
+}
+
+type batchAccountsRequest struct {
+	Balance        bool `json:"balance,omitempty"`
+	Transactions   bool `json:"transactions,omitempty"`
+	Pending        bool `json:"pending,omitempty"`
+	StandingOrders bool `json:"standing_orders,omitempty"`
+	DirectDebits   bool `json:"direct_debits,omitempty"`
+}
+
+type BatchAccount struct {
+	Account
+	Balance             *AccountBalance        `json:"balance"`
+	Transactions        []AccountTransaction   `json:"transactions"`
+	PendingTransactions []AccountTransaction   `json:"pending"`
+	StandingOrders      []AccountStandingOrder `json:"standing_orders"`
+	DirectDebits        []AccountDirectDebit   `json:"direct_debits"`
+}
+
+// UnmarshalJSON decodes the batch account. The embedded Account is decoded
+// separately as its UnmarshalJSON method would otherwise be promoted, the
+// batch results are removed from the account's Extra.
+func (a *BatchAccount) UnmarshalJSON(data []byte) error {
+	results := struct {
+		Balance             *AccountBalance        `json:"balance"`
+		Transactions        []AccountTransaction   `json:"transactions"`
+		PendingTransactions []AccountTransaction   `json:"pending"`
+		StandingOrders      []AccountStandingOrder `json:"standing_orders"`
+		DirectDebits        []AccountDirectDebit   `json:"direct_debits"`
+	}{}
+
+	if err := a.Account.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, &results); err != nil {
+		return err
+	}
+
+	batchFields := jsonFieldNames(reflect.TypeOf(results))
+
+	for key := range a.Extra {
+		if batchFields[strings.ToLower(key)] {
+			delete(a.Extra, key)
+		}
+	}
+
+	if len(a.Extra) == 0 {
+		a.Extra = nil
+	}
+
+	a.Balance = results.Balance
+	a.Transactions = results.Transactions
+	a.PendingTransactions = results.PendingTransactions
+	a.StandingOrders = results.StandingOrders
+	a.DirectDebits = results.DirectDebits
+
+	return nil
+}
+
+type BatchResult struct {
+	Accounts []BatchAccount `json:"accounts"`
+}
+
+// NewBatch creates a new Batch builder. Accounts are always included in the
+// batch, additional data is included by calling the builder methods.
+//
+// returns
+//   - a new batch builder
+func (t *TrueLayer) NewBatch() *Batch {
+	return &Batch{t: t}
+}
+
+// WithBalances includes the balance of every account in the batch.
+//
+// returns
+//   - the batch builder
+func (b *Batch) WithBalances() *Batch {
+	b.request.Accounts.Balance = true
+	return b
+}
+
+// WithTransactions includes the transactions of every account in the batch.
+//
+// params
+//   - opts - options for the transactions (optional)
+//
+// returns
+//   - the batch builder
+func (b *Batch) WithTransactions(opts *AccountOptions) *Batch {
+	b.request.Accounts.Transactions = true
+	b.opts = opts
+	return b
+}
+
+// WithPendingTransactions includes the pending transactions of every account
+// in the batch.
+//
+// returns
+//   - the batch builder
+func (b *Batch) WithPendingTransactions() *Batch {
+	b.request.Accounts.Pending = true
+	return b
+}
+
+// WithStandingOrders includes the standing orders of every account in the
+// batch.
+//
+// returns
+//   - the batch builder
+func (b *Batch) WithStandingOrders() *Batch {
+	b.request.Accounts.StandingOrders = true
+	return b
+}
+
+// WithDirectDebits includes the direct debits of every account in the batch.
+//
+// returns
+//   - the batch builder
+func (b *Batch) WithDirectDebits() *Batch {
+	b.request.Accounts.DirectDebits = true
+	return b
+}
+
+// Execute triggers the batch request, upon completion TrueLayer will call the
+// webhookURI and the results can be fetched using GetBatchAsyncRequest.
+//
+// params
+//   - accessToken - access token to get the data from
+//   - webhookURI - uri to access upon async job completion
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (b *Batch) Execute(accessToken string, webhookURI string) (*AsyncRequestResponse, error) {
+	return b.ExecuteWithContext(context.Background(), accessToken, webhookURI)
+}
+
+// ExecuteWithContext is the same as Execute but uses the provided context for
+// the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the data from
+//   - webhookURI - uri to access upon async job completion
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (b *Batch) ExecuteWithContext(ctx context.Context, accessToken string, webhookURI string) (*AsyncRequestResponse, error) {
+	u, err := buildURL(b.t.getBaseURL(), EndpointDataV1Batch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+	q.Add("async", "true")
+	q.Add("webhookURI", webhookURI)
+
+	if b.opts != nil {
+		if b.opts.From == nil || b.opts.To == nil {
+			return nil, ErrToFromNil
+		}
+
+		q.Add("to", b.opts.To.Format(time.RFC3339))
+		q.Add("from", b.opts.From.Format(time.RFC3339))
+	}
+
+	u.RawQuery = q.Encode()
+
+	res, err := b.t.doAuthorizedJSONRequest(ctx, http.MethodPost, u, accessToken, b.request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, parseErrorResponse(res)
+	}
+
+	resp := AsyncRequestResponse{}
+	err = json.NewDecoder(res.Body).Decode(&resp)
+
+	return &resp, err
+}
+
+// GetBatchAsyncRequest takes the result from a Webhook request and sends a
+// request to the correct endpoint to fetch the batch results.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+//
+// returns
+//   - the batch result
+//   - errors from the api request
+func (t *TrueLayer) GetBatchAsyncRequest(accessToken string, webhook *WebhookRequest) (*BatchResult, error) {
+	return t.GetBatchAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetBatchAsyncRequestWithContext is the same as GetBatchAsyncRequest but uses
+// the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+//
+// returns
+//   - the batch result
+//   - errors from the api request
+func (t *TrueLayer) GetBatchAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*BatchResult, error) {
+	batchResp := BatchResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &batchResp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchResp.Results, nil
+}
@@ -0,0 +1,825 @@
+package truelayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	EndpointDataV1Cards                   = "/data/v1/cards"
+	EndpointDataV1Card                    = "/data/v1/cards/%s"
+	EndpointDataV1CardBalance             = "/data/v1/cards/%s/balance"
+	EndpointDataV1CardTransactions        = "/data/v1/cards/%s/transactions"
+	EndpointDataV1CardPendingTransactions = "/data/v1/cards/%s/transactions/pending"
+)
+
+type Card struct {
+	AccountID         string    `json:"account_id"`
+	CardNetwork       string    `json:"card_network"`
+	CardType          string    `json:"card_type"`
+	Currency          string    `json:"currency"`
+	DisplayName       string    `json:"display_name"`
+	PartialCardNumber string    `json:"partial_card_number"`
+	NameOnCard        string    `json:"name_on_card"`
+	ValidFrom         string    `json:"valid_from"`
+	ValidTo           string    `json:"valid_to"`
+	UpdateTimestamp   time.Time `json:"update_timestamp"`
+	Provider          Provider  `json:"provider"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+	// Raw is the JSON the card was decoded from, only set when the client was
+	// created with WithRawJSON.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the card, keeping any fields which are not modelled.
+func (c *Card) UnmarshalJSON(data []byte) error {
+	type alias Card
+	return unmarshalModel(data, (*alias)(c), &c.Extra)
+}
+
+type CardBalance struct {
+	Currency             string    `json:"currency"`
+	Available            Money     `json:"available"`
+	Current              Money     `json:"current"`
+	CreditLimit          Money     `json:"credit_limit"`
+	LastStatementBalance Money     `json:"last_statement_balance"`
+	LastStatementDate    Timestamp `json:"last_statement_date"`
+	PaymentDue           Money     `json:"payment_due"`
+	PaymentDueDate       Timestamp `json:"payment_due_date"`
+	UpdateTimestamp      time.Time `json:"update_timestamp"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+	// Raw is the JSON the balance was decoded from, only set when the client was
+	// created with WithRawJSON.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the balance, setting the currency of the amounts and
+// keeping any fields which are not modelled.
+func (b *CardBalance) UnmarshalJSON(data []byte) error {
+	type alias CardBalance
+	return unmarshalModel(data, (*alias)(b), &b.Extra, &b.Available, &b.Current, &b.CreditLimit, &b.LastStatementBalance, &b.PaymentDue)
+}
+
+type CardTransaction struct {
+	TransactionID                   string                      `json:"transaction_id"`
+	NormalisedProviderTransactionID string                      `json:"normalised_provider_transaction_id"`
+	ProviderTransactionID           string                      `json:"provider_transaction_id"`
+	Timestamp                       Timestamp                   `json:"timestamp"`
+	Description                     string                      `json:"description"`
+	Amount                          Money                       `json:"amount"`
+	Currency                        string                      `json:"currency"`
+	TransactionType                 TransactionType             `json:"transaction_type"`
+	TransactionCategory             TransactionCategory         `json:"transaction_category"`
+	TransactionClassification       []TransactionClassification `json:"transaction_classification"`
+	MerchantName                    string                      `json:"merchant_name"`
+	RunningBalance                  RunningBalance              `json:"running_balance"`
+	Meta                            CardTransactionMeta         `json:"meta"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+	// Raw is the JSON the transaction was decoded from, only set when the client
+	// was created with WithRawJSON.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the transaction, setting the currency of the amounts
+// and keeping any fields which are not modelled.
+func (tx *CardTransaction) UnmarshalJSON(data []byte) error {
+	type alias CardTransaction
+	return unmarshalModel(data, (*alias)(tx), &tx.Extra, &tx.Amount)
+}
+
+type CardTransactionMeta struct {
+	CardNumber                  string `json:"cardNumber"`
+	Location                    string `json:"location"`
+	ProviderTransactionCategory string `json:"provider_transaction_category"`
+	ProviderReference           string `json:"provider_reference"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the transaction metadata, keeping any fields which are
+// not modelled.
+func (m *CardTransactionMeta) UnmarshalJSON(data []byte) error {
+	type alias CardTransactionMeta
+	return unmarshalModel(data, (*alias)(m), &m.Extra)
+}
+
+// GetCards retrieves the cards associated with the provided access token.
+//
+// params
+//   - accessToken - access token to get the cards from
+//
+// returns
+//   - list of cards
+//   - errors from the api request
+func (t *TrueLayer) GetCards(accessToken string) ([]Card, error) {
+	return t.GetCardsWithContext(context.Background(), accessToken)
+}
+
+// GetCardsWithContext is the same as GetCards but uses the provided context for
+// the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the cards from
+//
+// returns
+//   - list of cards
+//   - errors from the api request
+func (t *TrueLayer) GetCardsWithContext(ctx context.Context, accessToken string) ([]Card, error) {
+	u, err := buildURL(t.getBaseURL(), EndpointDataV1Cards)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.getCards(ctx, u, accessToken)
+}
+
+// GetCardsWithTokenSource is the same as GetCardsWithContext but takes the
+// access token from the provided TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//
+// returns
+//   - list of cards
+//   - errors from the api request
+func (t *TrueLayer) GetCardsWithTokenSource(ctx context.Context, source *TokenSource) ([]Card, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetCardsWithContext(ctx, accessToken)
+}
+
+// GetCardsAsync triggers an async request to TrueLayer to get a list of all
+// cards associated to the access token.
+//
+// params
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetCardsAsync(accessToken string, webhookURI string) (*AsyncRequestResponse, error) {
+	return t.GetCardsAsyncWithContext(context.Background(), accessToken, webhookURI)
+}
+
+// GetCardsAsyncWithContext is the same as GetCardsAsync but uses the provided
+// context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetCardsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, EndpointDataV1Cards, accessToken, webhookURI, nil)
+}
+
+// GetCardsAsyncRequest takes the result from a Webhook request and sends a
+// request to the correct endpoint to fetch the cards.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetCardsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]Card, error) {
+	return t.GetCardsAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetCardsAsyncRequestWithContext is the same as GetCardsAsyncRequest but uses
+// the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetCardsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]Card, error) {
+	resp := CardsResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
+}
+
+// getCards takes the Card data URL then does an authenticated GET request
+// decoding the response and returning the correct data structure.
+//
+// params
+//   - ctx - context for the request
+//   - u - the URL to request
+//   - accessToken - the card's associated access token
+func (t *TrueLayer) getCards(ctx context.Context, u *url.URL, accessToken string) ([]Card, error) {
+	res, err := t.doAuthorizedGetRequest(ctx, u, accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, parseErrorResponse(res)
+	}
+
+	cardsResp := CardsResponse{}
+	err = t.decodeJSON(res.Body, &cardsResp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cardsResp.Results, nil
+}
+
+// GetCard retrieves the specified card based on accountID, this card must be
+// associated to the provided accessToken or an error will occur.
+//
+// params
+//   - accessToken - access token to get the card from
+//   - accountID - the card's account ID to get
+//
+// returns
+//   - the card
+//   - errors from the api request
+func (t *TrueLayer) GetCard(accessToken string, accountID string) (*Card, error) {
+	return t.GetCardWithContext(context.Background(), accessToken, accountID)
+}
+
+// GetCardWithContext is the same as GetCard but uses the provided context for
+// the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the card from
+//   - accountID - the card's account ID to get
+//
+// returns
+//   - the card
+//   - errors from the api request
+func (t *TrueLayer) GetCardWithContext(ctx context.Context, accessToken string, accountID string) (*Card, error) {
+	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1Card, accountID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := t.getCards(ctx, u, accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cards) == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &cards[0], nil
+}
+
+// GetCardWithTokenSource is the same as GetCardWithContext but takes the access
+// token from the provided TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//   - accountID - the card's account ID to get
+//
+// returns
+//   - the card
+//   - errors from the api request
+func (t *TrueLayer) GetCardWithTokenSource(ctx context.Context, source *TokenSource, accountID string) (*Card, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetCardWithContext(ctx, accessToken, accountID)
+}
+
+// GetCardAsync triggers an async request to TrueLayer to get the specified card
+// based on the accountID, this card must be associated to the provided
+// accessToken or an error will occur.
+//
+// params
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - the card's account ID to get
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetCardAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
+	return t.GetCardAsyncWithContext(context.Background(), accessToken, webhookURI, accountID)
+}
+
+// GetCardAsyncWithContext is the same as GetCardAsync but uses the provided
+// context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - the card's account ID to get
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetCardAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, fmt.Sprintf(EndpointDataV1Card, accountID), accessToken, webhookURI, nil)
+}
+
+// GetCardAsyncRequest takes the result from a Webhook request and sends a
+// request to the correct endpoint to fetch the card.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetCardAsyncRequest(accessToken string, webhook *WebhookRequest) (*Card, error) {
+	return t.GetCardAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetCardAsyncRequestWithContext is the same as GetCardAsyncRequest but uses
+// the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetCardAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*Card, error) {
+	resp := CardsResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Results) == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &resp.Results[0], nil
+}
+
+// GetCardBalance retrieves the specified card's balance this card must be
+// associated to the provided accessToken or an error will occur.
+//
+// params
+//   - accessToken - access token to get the card from
+//   - accountID - the card's account ID to get
+//
+// returns
+//   - the balance
+//   - errors from the api request
+func (t *TrueLayer) GetCardBalance(accessToken string, accountID string) (*CardBalance, error) {
+	return t.GetCardBalanceWithContext(context.Background(), accessToken, accountID)
+}
+
+// GetCardBalanceWithContext is the same as GetCardBalance but uses the provided
+// context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the card from
+//   - accountID - the card's account ID to get
+//
+// returns
+//   - the balance
+//   - errors from the api request
+func (t *TrueLayer) GetCardBalanceWithContext(ctx context.Context, accessToken string, accountID string) (*CardBalance, error) {
+	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1CardBalance, accountID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.doAuthorizedGetRequest(ctx, u, accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, parseErrorResponse(res)
+	}
+
+	balanceResp := CardBalanceResponse{}
+	err = t.decodeJSON(res.Body, &balanceResp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(balanceResp.Results) == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &balanceResp.Results[0], nil
+}
+
+// GetCardBalanceWithTokenSource is the same as GetCardBalanceWithContext but
+// takes the access token from the provided TokenSource, refreshing it if
+// required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//   - accountID - the card's account ID to get
+//
+// returns
+//   - the balance
+//   - errors from the api request
+func (t *TrueLayer) GetCardBalanceWithTokenSource(ctx context.Context, source *TokenSource, accountID string) (*CardBalance, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetCardBalanceWithContext(ctx, accessToken, accountID)
+}
+
+// GetCardBalanceAsync triggers an async request to TrueLayer to get the
+// specified card balance based on the accountID, this card must be associated
+// to the provided accessToken or an error will occur.
+//
+// params
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - the card's account ID to get
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetCardBalanceAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
+	return t.GetCardBalanceAsyncWithContext(context.Background(), accessToken, webhookURI, accountID)
+}
+
+// GetCardBalanceAsyncWithContext is the same as GetCardBalanceAsync but uses
+// the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - the card's account ID to get
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetCardBalanceAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, fmt.Sprintf(EndpointDataV1CardBalance, accountID), accessToken, webhookURI, nil)
+}
+
+// GetCardBalanceAsyncRequest takes the result from a Webhook request and sends
+// a request to the correct endpoint to fetch the balance.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetCardBalanceAsyncRequest(accessToken string, webhook *WebhookRequest) (*CardBalance, error) {
+	return t.GetCardBalanceAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetCardBalanceAsyncRequestWithContext is the same as
+// GetCardBalanceAsyncRequest but uses the provided context for the underlying
+// request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetCardBalanceAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*CardBalance, error) {
+	resp := CardBalanceResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Results) == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &resp.Results[0], nil
+}
+
+// GetCardTransactions retrieves the specified card's transactions this card
+// must be associated to the provided accessToken or an error will occur.
+//
+// params
+//   - accessToken - access token to get the card from
+//   - accountID - the card's account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions
+//   - errors from the api request
+func (t *TrueLayer) GetCardTransactions(accessToken string, accountID string, opts *AccountOptions) ([]CardTransaction, error) {
+	return t.GetCardTransactionsWithContext(context.Background(), accessToken, accountID, opts)
+}
+
+// GetCardTransactionsWithContext is the same as GetCardTransactions but uses
+// the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the card from
+//   - accountID - the card's account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions
+//   - errors from the api request
+func (t *TrueLayer) GetCardTransactionsWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) ([]CardTransaction, error) {
+	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1CardTransactions, accountID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.getCardTransactions(ctx, u, accessToken, opts)
+}
+
+// GetCardTransactionsWithTokenSource is the same as
+// GetCardTransactionsWithContext but takes the access token from the provided
+// TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//   - accountID - the card's account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions
+//   - errors from the api request
+func (t *TrueLayer) GetCardTransactionsWithTokenSource(ctx context.Context, source *TokenSource, accountID string, opts *AccountOptions) ([]CardTransaction, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetCardTransactionsWithContext(ctx, accessToken, accountID, opts)
+}
+
+// GetCardTransactionsAsync triggers an async request to TrueLayer to get the
+// specified card transactions based on the accountID, this card must be
+// associated to the provided accessToken or an error will occur.
+//
+// params
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - the card's account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetCardTransactionsAsync(accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error) {
+	return t.GetCardTransactionsAsyncWithContext(context.Background(), accessToken, webhookURI, accountID, opts)
+}
+
+// GetCardTransactionsAsyncWithContext is the same as GetCardTransactionsAsync
+// but uses the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - the card's account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetCardTransactionsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, fmt.Sprintf(EndpointDataV1CardTransactions, accountID), accessToken, webhookURI, opts)
+}
+
+// GetCardTransactionsAsyncRequest takes the result from a Webhook request and
+// sends a request to the correct endpoint to fetch the transactions.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetCardTransactionsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]CardTransaction, error) {
+	return t.GetCardTransactionsAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetCardTransactionsAsyncRequestWithContext is the same as
+// GetCardTransactionsAsyncRequest but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetCardTransactionsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]CardTransaction, error) {
+	resp := CardTransactionsResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
+}
+
+// GetCardPendingTransactions retrieves the specified card's pending
+// transactions this card must be associated to the provided accessToken or an
+// error will occur.
+//
+// params
+//   - accessToken - access token to get the card from
+//   - accountID - the card's account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions
+//   - errors from the api request
+func (t *TrueLayer) GetCardPendingTransactions(accessToken string, accountID string, opts *AccountOptions) ([]CardTransaction, error) {
+	return t.GetCardPendingTransactionsWithContext(context.Background(), accessToken, accountID, opts)
+}
+
+// GetCardPendingTransactionsWithContext is the same as
+// GetCardPendingTransactions but uses the provided context for the underlying
+// request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the card from
+//   - accountID - the card's account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions
+//   - errors from the api request
+func (t *TrueLayer) GetCardPendingTransactionsWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) ([]CardTransaction, error) {
+	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1CardPendingTransactions, accountID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.getCardTransactions(ctx, u, accessToken, opts)
+}
+
+// GetCardPendingTransactionsWithTokenSource is the same as
+// GetCardPendingTransactionsWithContext but takes the access token from the
+// provided TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//   - accountID - the card's account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions
+//   - errors from the api request
+func (t *TrueLayer) GetCardPendingTransactionsWithTokenSource(ctx context.Context, source *TokenSource, accountID string, opts *AccountOptions) ([]CardTransaction, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetCardPendingTransactionsWithContext(ctx, accessToken, accountID, opts)
+}
+
+// GetCardPendingTransactionsAsync triggers an async request to TrueLayer to get
+// the specified card pending transactions based on the accountID, this card
+// must be associated to the provided accessToken or an error will occur.
+//
+// params
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - the card's account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetCardPendingTransactionsAsync(accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error) {
+	return t.GetCardPendingTransactionsAsyncWithContext(context.Background(), accessToken, webhookURI, accountID, opts)
+}
+
+// GetCardPendingTransactionsAsyncWithContext is the same as
+// GetCardPendingTransactionsAsync but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//   - accountID - the card's account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetCardPendingTransactionsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, fmt.Sprintf(EndpointDataV1CardPendingTransactions, accountID), accessToken, webhookURI, opts)
+}
+
+// GetCardPendingTransactionsAsyncRequest takes the result from a Webhook
+// request and sends a request to the correct endpoint to fetch the
+// transactions.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetCardPendingTransactionsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]CardTransaction, error) {
+	return t.GetCardPendingTransactionsAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetCardPendingTransactionsAsyncRequestWithContext is the same as
+// GetCardPendingTransactionsAsyncRequest but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetCardPendingTransactionsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]CardTransaction, error) {
+	resp := CardTransactionsResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
+}
+
+// getCardTransactions retrieves the specified card's transactions either
+// pending or not depending on the passed URL.
+//
+// params
+//   - ctx - context for the request
+//   - url - the url to request
+//     (EndpointDataV1CardTransactions|EndpointDataV1CardPendingTransactions)
+//   - accessToken - access token to get the card from
+//   - opts - options for the request
+//
+// returns
+//   - the transactions
+//   - errors from the api request
+func (t *TrueLayer) getCardTransactions(ctx context.Context, url *url.URL, accessToken string, opts *AccountOptions) ([]CardTransaction, error) {
+	if opts != nil {
+		if opts.From == nil || opts.To == nil {
+			return nil, ErrToFromNil
+		}
+
+		q := url.Query()
+		q.Add("to", opts.To.Format(time.RFC3339))
+		q.Add("from", opts.From.Format(time.RFC3339))
+		url.RawQuery = q.Encode()
+	}
+
+	res, err := t.doAuthorizedGetRequest(ctx, url, accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, parseErrorResponse(res)
+	}
+
+	transactionsResp := CardTransactionsResponse{}
+	err = t.decodeJSON(res.Body, &transactionsResp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transactionsResp.Results, nil
+}
@@ -1,82 +1,173 @@
 package truelayer
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 type TrueLayer struct {
 	clientID     string
 	clientSecret string
-	sandbox      bool
-	httpClient   httpClient
+	environment  Environment
+	httpClient   HTTPClient
+	retryPolicy  *RetryPolicy
+	logger       Logger
+	userAgent    string
+	timeout      time.Duration
+	middleware   []Middleware
+
+	verifyWebhooks  bool
+	webhookVerifier *WebhookVerifier
+
+	rawJSON bool
+
+	clientTokensMu     sync.Mutex
+	clientTokens       map[string]*Token
+	clientTokenFetches map[string]*clientTokenFetch
 }
 
 const (
-	baseURL        = "https://api.truelayer.com"
-	baseSandboxURL = "https://api.truelayer-sandbox.com"
-
 	EndpointDataV1Results = "/data/v1/results/%s"
 
-	ErrRequestBodyNil = StrError("request body is nil")
+	ErrRequestBodyNil          = StrError("request body is nil")
+	ErrWebhookRequestNil       = StrError("webhook request must not be nil")
+	ErrAsyncRequestNotComplete = StrError("async request has not completed")
+	ErrNoResults               = StrError("response contained no results")
 )
 
-// httpClient is an interface to define the methods required from any kind of
-// HTTP Client that will be used by the TrueLayer Client.
-type httpClient interface {
-	Do(req *http.Request) (*http.Response, error)
+// New creates a new instance of the TrueLayer go client. By default the client
+// uses the production environment, a new *http.Client and DefaultRetryPolicy,
+// these can be changed using options e.g. WithSandbox or WithHTTPClient.
+//
+// params
+//   - clientID - TrueLayer client_id
+//   - clientSecret - TrueLayer client_secret
+//   - opts - options to configure the client
+//
+// returns
+//   - instance of TrueLayer client
+func New(clientID, clientSecret string, opts ...Option) *TrueLayer {
+	retryPolicy := DefaultRetryPolicy
+
+	t := &TrueLayer{
+		clientID:       clientID,
+		clientSecret:   clientSecret,
+		environment:    EnvironmentProduction,
+		httpClient:     &http.Client{},
+		retryPolicy:    &retryPolicy,
+		verifyWebhooks: true,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	t.httpClient = wrapHTTPClient(t.httpClient, t.middleware)
+
+	if t.verifyWebhooks {
+		t.webhookVerifier = t.NewWebhookVerifier()
+	}
+
+	return t
 }
 
-// New creates a new instance of the TrueLayer go client. This is done to allow
-// for mocking within user implementation to allow for greater test coverage.
+// NewWithHTTPClient creates a new instance of the TrueLayer go client, with a
+// custom HTTP Client.
+//
+// Deprecated: use New with WithSandbox and WithHTTPClient.
 //
 // params
 //   - clientID - TrueLayer client_id
 //   - clientSecret - TrueLayer client_secret
 //   - sandbox - true if using the sandbox environment
+//   - httpClient - custom HTTP client for the TrueLayer client to use
 //
 // returns
 //   - instance of TrueLayer client
-func New(clientID, clientSecret string, sandbox bool) *TrueLayer {
-	return NewWithHTTPClient(clientID, clientSecret, sandbox, &http.Client{})
+func NewWithHTTPClient(clientID, clientSecret string, sandbox bool, httpClient HTTPClient) *TrueLayer {
+	return New(clientID, clientSecret, WithSandbox(sandbox), WithHTTPClient(httpClient))
 }
 
-// NewWithHTTPClient creates a new instance of the TrueLayer go client, with a
-// custom HTTP Client. This is done to allow for mocking within user
-// implementation to allow for greater test coverage.
+// NewWithEnvironment creates a new instance of the TrueLayer go client, with a
+// custom environment and HTTP Client.
+//
+// Deprecated: use New with WithEnvironment and WithHTTPClient.
 //
 // params
 //   - clientID - TrueLayer client_id
 //   - clientSecret - TrueLayer client_secret
-//   - sandbox - true if using the sandbox environment
+//   - environment - the hosts for the TrueLayer client to use
 //   - httpClient - custom HTTP client for the TrueLayer client to use
 //
 // returns
 //   - instance of TrueLayer client
-func NewWithHTTPClient(clientID, clientSecret string, sandbox bool, httpClient httpClient) *TrueLayer {
-	return &TrueLayer{
-		clientID:     clientID,
-		clientSecret: clientSecret,
-		sandbox:      sandbox,
-		httpClient:   httpClient,
-	}
+func NewWithEnvironment(clientID, clientSecret string, environment Environment, httpClient HTTPClient) *TrueLayer {
+	return New(clientID, clientSecret, WithEnvironment(environment), WithHTTPClient(httpClient))
 }
 
 // doAuthorizedGetRequest executes a request with an Authorization header with
 // the provided accessToken to the provided URL.
 //
 // params
+//   - ctx - context for the request
+//   - url - the URL to make a request
+//   - accessToken - the access token to use
+//
+// returns
+//   - the http response
+//   - any errors that have occurred
+func (t *TrueLayer) doAuthorizedGetRequest(ctx context.Context, url *url.URL, accessToken string) (*http.Response, error) {
+	return t.doAuthorizedRequest(ctx, http.MethodGet, url, accessToken, nil)
+}
+
+// doAuthorizedJSONRequest executes a request with an Authorization header with
+// the provided accessToken to the provided URL, encoding the provided payload as
+// the JSON request body.
+//
+// params
+//   - ctx - context for the request
+//   - method - http request method
+//   - url - the URL to make a request
+//   - accessToken - the access token to use
+//   - payload - value to be JSON encoded for the request body
+//
+// returns
+//   - the http response
+//   - any errors that have occurred
+func (t *TrueLayer) doAuthorizedJSONRequest(ctx context.Context, method string, url *url.URL, accessToken string, payload interface{}) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.doAuthorizedRequest(ctx, method, url, accessToken, bytes.NewReader(body))
+}
+
+// doAuthorizedRequest executes a request with an Authorization header with the
+// provided accessToken to the provided URL. A JSON Content-Type header is added
+// when a body is provided.
+//
+// params
+//   - ctx - context for the request
+//   - method - http request method
 //   - url - the URL to make a request
 //   - accessToken - the access token to use
+//   - body - request body (optional)
 //
 // returns
 //   - the http response
 //   - any errors that have occurred
-func (t *TrueLayer) doAuthorizedGetRequest(url *url.URL, accessToken string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, url.String(), nil)
+func (t *TrueLayer) doAuthorizedRequest(ctx context.Context, method string, url *url.URL, accessToken string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), body)
 
 	if err != nil {
 		return nil, err
@@ -84,13 +175,17 @@ func (t *TrueLayer) doAuthorizedGetRequest(url *url.URL, accessToken string) (*h
 
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 
-	res, err := t.httpClient.Do(req)
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	res, err := t.do(req)
 
 	return res, err
 }
 
 // buildURL takes a base URL as well as a path and combines them into a url.URL
-// object.
+// object. Any path on the base URL is kept as a prefix.
 //
 // params
 //   - baseURL - the base url to use
@@ -106,64 +201,89 @@ func buildURL(baseURL string, path string) (*url.URL, error) {
 		return nil, err
 	}
 
-	u.Path = path
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
 
 	return u, nil
 }
 
 // parseErrorResponse takes a http.Response object and decodes the body into an
-// ErrorResponse object which implements the `error` interface.
+// ErrorResponse object which implements the `error` interface. The status code,
+// request ids and raw body are always set, even when the body is not JSON.
 //
 // params
 //   - res - the http response to decode
 //
 // returns
-//   - err - the decoded error or the error returned from decoding
+//   - err - the decoded error or the error returned from reading the body
 func parseErrorResponse(res *http.Response) (err error) {
-	respErr := &ErrorResponse{}
-	err = json.NewDecoder(res.Body).Decode(respErr)
+	body, err := io.ReadAll(res.Body)
 	if err != nil {
 		return err
 	}
+
+	respErr := &ErrorResponse{}
+
+	// the body is not always JSON e.g. HTML gateway errors, the status code
+	// is still enough to classify these
+	_ = json.Unmarshal(body, respErr)
+
+	respErr.StatusCode = res.StatusCode
+	respErr.RequestID = res.Header.Get(HeaderRequestID)
+
+	if respErr.RequestID == "" && res.Request != nil {
+		respErr.RequestID = res.Request.Header.Get(HeaderRequestID)
+	}
+	respErr.CorrelationID = res.Header.Get(HeaderCorrelationID)
+	respErr.Body = body
+
 	return respErr
 }
 
-// getBaseURL parses the baseAuthURL for either the sandbox or non-sandbox
-// TrueLayer environments and returns them. Using a utility method to reduce
-// code duplication.
+// getBaseURL returns the Data API base URL for the client's environment. Using
+// a utility method to reduce code duplication.
 //
 // returns
 //   - the base url
 func (t *TrueLayer) getBaseURL() string {
-	if t.sandbox {
-		return baseSandboxURL
-	}
-
-	return baseURL
+	return t.environment.APIURL
 }
 
 // HandleAsyncWebhookRequest will take an HTTP request and return the
 // WebhookRequest object or an error. This is used as a part of the async flow
-// for the TrueLayer api.
+// for the TrueLayer api. The `Tl-Signature` header is verified before the body
+// is decoded unless verification was disabled with WithWebhookVerification.
 //
 // params
 //   - req - the http request to handle
 //
 // returns
 //   - the webhook request
+//   - *SignatureError if the signature is missing or invalid
 //   - error if an error occurs
 func (t *TrueLayer) HandleAsyncWebhookRequest(req *http.Request) (*WebhookRequest, error) {
 	if req.Body == nil {
 		return nil, ErrRequestBodyNil
 	}
 
-	return nil, nil
+	if t.webhookVerifier != nil {
+		err := t.webhookVerifier.VerifyRequest(req)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t.HandleAsyncWebhookRequestBody(req.Body)
 }
 
 // HandleAsyncWebhookRequestBody will take an io.ReadCloser and return the
 // WebhookRequest object or an error. This is used as a part of the async flow
 // for the TrueLayer api.
 //
+// The body is not verified, anyone can send an unsigned body to a webhook
+// endpoint. Use HandleAsyncWebhookRequest or verify the request with
+// WebhookVerifier.VerifyRequest before calling this.
+//
 // params
 //   - body - the readcloser to decode
 //
@@ -188,3 +308,49 @@ func (t *TrueLayer) HandleAsyncWebhookRequestBody(body io.ReadCloser) (*WebhookR
 
 	return req, nil
 }
+
+// GetAsyncRequestResult takes the result from a Webhook request and fetches the
+// results of the async task, decoding them into v. The typed *AsyncRequest
+// methods should be preferred, this is useful for endpoints without one.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+//   - v - pointer to the response type of the endpoint that triggered the
+//     request e.g. *AccountsResponse, *AccountTransactionsResponse
+//
+// returns
+//   - errors from the api request
+func (t *TrueLayer) GetAsyncRequestResult(accessToken string, webhook *WebhookRequest, v interface{}) error {
+	return t.GetAsyncRequestResultWithContext(context.Background(), accessToken, webhook, v)
+}
+
+// GetAsyncRequestResultWithContext is the same as GetAsyncRequestResult but
+// uses the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+//   - v - pointer to the response type of the endpoint that triggered the
+//     request e.g. *AccountsResponse, *AccountTransactionsResponse
+//
+// returns
+//   - errors from the api request
+func (t *TrueLayer) GetAsyncRequestResultWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest, v interface{}) error {
+	if webhook == nil {
+		return ErrWebhookRequestNil
+	}
+
+	done, err := t.getAsyncResult(ctx, accessToken, webhook.TaskID, v)
+
+	if err != nil {
+		return err
+	}
+
+	if !done {
+		return ErrAsyncRequestNotComplete
+	}
+
+	return nil
+}
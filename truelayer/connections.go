@@ -0,0 +1,194 @@
+package truelayer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+const (
+	authExtendEndpoint = "/connections/extend"
+	authReauthEndpoint = "/v1/reauthuri"
+
+	ErrConsentExpired     = StrError("consent has expired, re-authentication is required")
+	ErrReauthLinkNotFound = StrError("re-authentication link was not returned")
+)
+
+// ExtendConnectionResponse is the result of extending a connection. When the
+// provider allows the connection to be extended the access token fields are
+// set, otherwise the user must re-authenticate using AuthURI.
+type ExtendConnectionResponse struct {
+	AccessTokenResponse
+	AuthURI string `json:"auth_uri"`
+}
+
+// RequiresReauthentication returns whether the connection could not be
+// extended and the user must be sent to AuthURI.
+//
+// returns
+//   - true if re-authentication is required
+func (res *ExtendConnectionResponse) RequiresReauthentication() bool {
+	return res.AccessToken == "" && res.AuthURI != ""
+}
+
+// extendConnectionRequest is the request body for extending a connection.
+type extendConnectionRequest struct {
+	ClientID                  string `json:"client_id"`
+	ClientSecret              string `json:"client_secret"`
+	RefreshToken              string `json:"refresh_token"`
+	RedirectURI               string `json:"redirect_uri"`
+	UserHasReconfirmedConsent bool   `json:"user_has_reconfirmed_consent"`
+}
+
+// reauthLinkRequest is the request body for generating a re-authentication
+// link.
+type reauthLinkRequest struct {
+	ResponseType string `json:"response_type"`
+	ClientID     string `json:"client_id"`
+	RedirectURI  string `json:"redirect_uri"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// reauthLinkResponse is the response from generating a re-authentication link.
+type reauthLinkResponse struct {
+	Success bool   `json:"success"`
+	Result  string `json:"result"`
+}
+
+// ExtendConnection extends the consent of a connection. The user should
+// reconfirm their consent in your application first, whether they have is sent
+// to TrueLayer as `user_has_reconfirmed_consent`. Some providers do not allow
+// extension in which case RequiresReauthentication is true and the user must
+// be sent to the returned AuthURI.
+//
+// params
+//   - refreshToken - refresh token of the connection to extend
+//   - redirURI - where to redirect the user if re-authentication is required
+//   - userHasReconfirmedConsent - true if the user has reconfirmed their
+//     consent in your application
+//
+// returns
+//   - the new tokens or the re-authentication link
+//   - any errors that have occurred including API errors
+func (t *TrueLayer) ExtendConnection(refreshToken string, redirURI *url.URL, userHasReconfirmedConsent bool) (*ExtendConnectionResponse, error) {
+	return t.ExtendConnectionWithContext(context.Background(), refreshToken, redirURI, userHasReconfirmedConsent)
+}
+
+// ExtendConnectionWithContext is the same as ExtendConnection but uses the
+// provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - refreshToken - refresh token of the connection to extend
+//   - redirURI - where to redirect the user if re-authentication is required
+//   - userHasReconfirmedConsent - true if the user has reconfirmed their
+//     consent in your application
+//
+// returns
+//   - the new tokens or the re-authentication link
+//   - any errors that have occurred including API errors
+func (t *TrueLayer) ExtendConnectionWithContext(ctx context.Context, refreshToken string, redirURI *url.URL, userHasReconfirmedConsent bool) (*ExtendConnectionResponse, error) {
+	if redirURI == nil {
+		return nil, ErrRedirectURINil
+	}
+
+	u, err := buildURL(t.getAuthBaseURL(), authExtendEndpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.doRequestWithJSONBody(ctx, http.MethodPost, u.String(), extendConnectionRequest{
+		ClientID:                  t.clientID,
+		ClientSecret:              t.clientSecret,
+		RefreshToken:              refreshToken,
+		RedirectURI:               redirURI.String(),
+		UserHasReconfirmedConsent: userHasReconfirmedConsent,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, parseErrorResponse(res)
+	}
+
+	extendResp := &ExtendConnectionResponse{}
+	err = json.NewDecoder(res.Body).Decode(extendResp)
+
+	return extendResp, err
+}
+
+// GetReauthenticationLink generates a link to re-authenticate an existing
+// connection, the link is pre-filled with the connection's provider and
+// scopes. This is used when a connection cannot be extended.
+//
+// params
+//   - refreshToken - refresh token of the connection to re-authenticate
+//   - redirURI - where to redirect the user after re-authenticating
+//
+// returns
+//   - link - the re-authentication link
+//   - err - ErrReauthLinkNotFound if TrueLayer did not return a link, any other
+//     errors that have occurred including API errors
+func (t *TrueLayer) GetReauthenticationLink(refreshToken string, redirURI *url.URL) (link string, err error) {
+	return t.GetReauthenticationLinkWithContext(context.Background(), refreshToken, redirURI)
+}
+
+// GetReauthenticationLinkWithContext is the same as GetReauthenticationLink but
+// uses the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - refreshToken - refresh token of the connection to re-authenticate
+//   - redirURI - where to redirect the user after re-authenticating
+//
+// returns
+//   - link - the re-authentication link
+//   - err - ErrReauthLinkNotFound if TrueLayer did not return a link, any other
+//     errors that have occurred including API errors
+func (t *TrueLayer) GetReauthenticationLinkWithContext(ctx context.Context, refreshToken string, redirURI *url.URL) (link string, err error) {
+	if redirURI == nil {
+		return link, ErrRedirectURINil
+	}
+
+	u, err := buildURL(t.getAuthBaseURL(), authReauthEndpoint)
+
+	if err != nil {
+		return link, err
+	}
+
+	res, err := t.doRequestWithJSONBody(ctx, http.MethodPost, u.String(), reauthLinkRequest{
+		ResponseType: "code",
+		ClientID:     t.clientID,
+		RedirectURI:  redirURI.String(),
+		RefreshToken: refreshToken,
+	})
+
+	if err != nil {
+		return link, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return link, parseErrorResponse(res)
+	}
+
+	reauthResp := reauthLinkResponse{}
+	err = json.NewDecoder(res.Body).Decode(&reauthResp)
+
+	if err != nil {
+		return link, err
+	}
+
+	if !reauthResp.Success || reauthResp.Result == "" {
+		return link, ErrReauthLinkNotFound
+	}
+
+	return reauthResp.Result, nil
+}
@@ -0,0 +1,73 @@
+package truelayer
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	HeaderPSUIP = "X-PSU-IP"
+)
+
+type psuIPContextKey struct{}
+
+type requestIDContextKey struct{}
+
+// ContextWithPSUIP attaches the end user's IP address to the context. When
+// passed to any of the *WithContext methods it is sent as the `X-PSU-IP`
+// header, marking the request as user present so that it is not rate limited
+// as an unattended request.
+//
+// params
+//   - ctx - the parent context
+//   - ip - the end user's IP address
+//
+// returns
+//   - the context with the IP address
+func ContextWithPSUIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, psuIPContextKey{}, ip)
+}
+
+// ContextWithRequestID attaches a request or correlation ID to the context.
+// When passed to any of the *WithContext methods it is sent as the
+// `X-Request-Id` header and set as the RequestID of any returned ErrorResponse
+// so it can be quoted in support tickets with TrueLayer.
+//
+// params
+//   - ctx - the parent context
+//   - id - the request id
+//
+// returns
+//   - the context with the request id
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID attached to the context using
+// ContextWithRequestID.
+//
+// params
+//   - ctx - the context
+//
+// returns
+//   - the request id or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// setContextHeaders sets the headers attached to the request's context.
+//
+// params
+//   - req - the request to set the headers on
+func setContextHeaders(req *http.Request) {
+	ctx := req.Context()
+
+	if ip, _ := ctx.Value(psuIPContextKey{}).(string); ip != "" {
+		req.Header.Set(HeaderPSUIP, ip)
+	}
+
+	if id := RequestIDFromContext(ctx); id != "" {
+		req.Header.Set(HeaderRequestID, id)
+	}
+}
@@ -0,0 +1,384 @@
+package truelayer
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// TransactionType is whether a transaction is money in or out of the account.
+type TransactionType string
+
+const (
+	TransactionTypeDebit  TransactionType = "DEBIT"
+	TransactionTypeCredit TransactionType = "CREDIT"
+)
+
+// TransactionCategory is the normalised category of a transaction.
+type TransactionCategory string
+
+const (
+	TransactionCategoryATM           TransactionCategory = "ATM"
+	TransactionCategoryBillPayment   TransactionCategory = "BILL_PAYMENT"
+	TransactionCategoryCash          TransactionCategory = "CASH"
+	TransactionCategoryCashback      TransactionCategory = "CASHBACK"
+	TransactionCategoryCheque        TransactionCategory = "CHEQUE"
+	TransactionCategoryCorrection    TransactionCategory = "CORRECTION"
+	TransactionCategoryCredit        TransactionCategory = "CREDIT"
+	TransactionCategoryDebit         TransactionCategory = "DEBIT"
+	TransactionCategoryDirectDebit   TransactionCategory = "DIRECT_DEBIT"
+	TransactionCategoryDividend      TransactionCategory = "DIVIDEND"
+	TransactionCategoryFeeCharge     TransactionCategory = "FEE_CHARGE"
+	TransactionCategoryInterest      TransactionCategory = "INTEREST"
+	TransactionCategoryOther         TransactionCategory = "OTHER"
+	TransactionCategoryPurchase      TransactionCategory = "PURCHASE"
+	TransactionCategoryStandingOrder TransactionCategory = "STANDING_ORDER"
+	TransactionCategoryTransfer      TransactionCategory = "TRANSFER"
+	TransactionCategoryUnknown       TransactionCategory = "UNKNOWN"
+)
+
+// TransactionClassification is an element of a transaction's classification,
+// the first element is the top level classification and the second, if any,
+// is the sub classification. Constants are provided for the top level
+// classifications.
+type TransactionClassification string
+
+const (
+	TransactionClassificationAutoAndTransport     TransactionClassification = "Auto & Transport"
+	TransactionClassificationBillsAndUtilities    TransactionClassification = "Bills and Utilities"
+	TransactionClassificationBusinessServices     TransactionClassification = "Business Services"
+	TransactionClassificationEducation            TransactionClassification = "Education"
+	TransactionClassificationEntertainment        TransactionClassification = "Entertainment"
+	TransactionClassificationFeesAndCharges       TransactionClassification = "Fees & Charges"
+	TransactionClassificationFoodAndDining        TransactionClassification = "Food & Dining"
+	TransactionClassificationGiftsAndDonations    TransactionClassification = "Gifts & Donations"
+	TransactionClassificationHealthAndFitness     TransactionClassification = "Health & Fitness"
+	TransactionClassificationHome                 TransactionClassification = "Home"
+	TransactionClassificationInvestments          TransactionClassification = "Investments"
+	TransactionClassificationPensionAndInsurances TransactionClassification = "Pension and insurances"
+	TransactionClassificationPersonalServices     TransactionClassification = "Personal Services"
+	TransactionClassificationPersonalCare         TransactionClassification = "Personal Care"
+	TransactionClassificationShopping             TransactionClassification = "Shopping"
+	TransactionClassificationTaxes                TransactionClassification = "Taxes"
+	TransactionClassificationTravel               TransactionClassification = "Travel"
+	TransactionClassificationUncategorized        TransactionClassification = "Uncategorized"
+	TransactionClassificationGambling             TransactionClassification = "Gambling"
+)
+
+// AccountType is the type of an account.
+type AccountType string
+
+const (
+	AccountTypeTransaction         AccountType = "TRANSACTION"
+	AccountTypeSavings             AccountType = "SAVINGS"
+	AccountTypeBusinessTransaction AccountType = "BUSINESS_TRANSACTION"
+	AccountTypeBusinessSavings     AccountType = "BUSINESS_SAVINGS"
+)
+
+// StandingOrderStatus is the status of a standing order.
+type StandingOrderStatus string
+
+const (
+	StandingOrderStatusActive   StandingOrderStatus = "Active"
+	StandingOrderStatusInactive StandingOrderStatus = "Inactive"
+)
+
+// StandingOrderFrequency is the frequency of a standing order. Some
+// frequencies have parameters e.g. "IntrvlWkDay:01:03", the constants are the
+// frequency without parameters as returned by Kind.
+type StandingOrderFrequency string
+
+const (
+	StandingOrderFrequencyEveryDay         StandingOrderFrequency = "EvryDay"
+	StandingOrderFrequencyEveryWorkingDay  StandingOrderFrequency = "EvryWorkgDay"
+	StandingOrderFrequencyIntervalWeekDay  StandingOrderFrequency = "IntrvlWkDay"
+	StandingOrderFrequencyWeekInMonthDay   StandingOrderFrequency = "WkInMnthDay"
+	StandingOrderFrequencyIntervalMonthDay StandingOrderFrequency = "IntrvlMnthDay"
+	StandingOrderFrequencyQuarterDay       StandingOrderFrequency = "QtrDay"
+)
+
+// DirectDebitStatus is the status of a direct debit.
+type DirectDebitStatus string
+
+const (
+	DirectDebitStatusActive   DirectDebitStatus = "Active"
+	DirectDebitStatusInactive DirectDebitStatus = "Inactive"
+)
+
+var (
+	transactionTypes = []string{
+		string(TransactionTypeDebit),
+		string(TransactionTypeCredit),
+	}
+
+	transactionCategories = []string{
+		string(TransactionCategoryATM),
+		string(TransactionCategoryBillPayment),
+		string(TransactionCategoryCash),
+		string(TransactionCategoryCashback),
+		string(TransactionCategoryCheque),
+		string(TransactionCategoryCorrection),
+		string(TransactionCategoryCredit),
+		string(TransactionCategoryDebit),
+		string(TransactionCategoryDirectDebit),
+		string(TransactionCategoryDividend),
+		string(TransactionCategoryFeeCharge),
+		string(TransactionCategoryInterest),
+		string(TransactionCategoryOther),
+		string(TransactionCategoryPurchase),
+		string(TransactionCategoryStandingOrder),
+		string(TransactionCategoryTransfer),
+		string(TransactionCategoryUnknown),
+	}
+
+	transactionClassifications = []string{
+		string(TransactionClassificationAutoAndTransport),
+		string(TransactionClassificationBillsAndUtilities),
+		string(TransactionClassificationBusinessServices),
+		string(TransactionClassificationEducation),
+		string(TransactionClassificationEntertainment),
+		string(TransactionClassificationFeesAndCharges),
+		string(TransactionClassificationFoodAndDining),
+		string(TransactionClassificationGiftsAndDonations),
+		string(TransactionClassificationHealthAndFitness),
+		string(TransactionClassificationHome),
+		string(TransactionClassificationInvestments),
+		string(TransactionClassificationPensionAndInsurances),
+		string(TransactionClassificationPersonalServices),
+		string(TransactionClassificationPersonalCare),
+		string(TransactionClassificationShopping),
+		string(TransactionClassificationTaxes),
+		string(TransactionClassificationTravel),
+		string(TransactionClassificationUncategorized),
+		string(TransactionClassificationGambling),
+	}
+
+	accountTypes = []string{
+		string(AccountTypeTransaction),
+		string(AccountTypeSavings),
+		string(AccountTypeBusinessTransaction),
+		string(AccountTypeBusinessSavings),
+	}
+
+	standingOrderStatuses = []string{
+		string(StandingOrderStatusActive),
+		string(StandingOrderStatusInactive),
+	}
+
+	standingOrderFrequencies = []string{
+		string(StandingOrderFrequencyEveryDay),
+		string(StandingOrderFrequencyEveryWorkingDay),
+		string(StandingOrderFrequencyIntervalWeekDay),
+		string(StandingOrderFrequencyWeekInMonthDay),
+		string(StandingOrderFrequencyIntervalMonthDay),
+		string(StandingOrderFrequencyQuarterDay),
+	}
+
+	directDebitStatuses = []string{
+		string(DirectDebitStatusActive),
+		string(DirectDebitStatusInactive),
+	}
+)
+
+// String returns the transaction type as returned by the API.
+func (e TransactionType) String() string { return string(e) }
+
+// IsKnown returns whether the transaction type is one of the constants.
+func (e TransactionType) IsKnown() bool { return isKnownEnum(string(e), transactionTypes) }
+
+// MarshalJSON encodes the transaction type as a string.
+func (e TransactionType) MarshalJSON() ([]byte, error) { return json.Marshal(string(e)) }
+
+// UnmarshalJSON decodes the transaction type, unknown values are preserved.
+func (e *TransactionType) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, transactionTypes)
+	*e = TransactionType(value)
+	return err
+}
+
+// String returns the transaction category as returned by the API.
+func (e TransactionCategory) String() string { return string(e) }
+
+// IsKnown returns whether the transaction category is one of the constants.
+func (e TransactionCategory) IsKnown() bool { return isKnownEnum(string(e), transactionCategories) }
+
+// MarshalJSON encodes the transaction category as a string.
+func (e TransactionCategory) MarshalJSON() ([]byte, error) { return json.Marshal(string(e)) }
+
+// UnmarshalJSON decodes the transaction category, unknown values are
+// preserved.
+func (e *TransactionCategory) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, transactionCategories)
+	*e = TransactionCategory(value)
+	return err
+}
+
+// String returns the transaction classification as returned by the API.
+func (e TransactionClassification) String() string { return string(e) }
+
+// IsKnown returns whether the transaction classification is one of the
+// constants, sub classifications are not known.
+func (e TransactionClassification) IsKnown() bool {
+	return isKnownEnum(string(e), transactionClassifications)
+}
+
+// MarshalJSON encodes the transaction classification as a string.
+func (e TransactionClassification) MarshalJSON() ([]byte, error) { return json.Marshal(string(e)) }
+
+// UnmarshalJSON decodes the transaction classification, unknown values are
+// preserved.
+func (e *TransactionClassification) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, transactionClassifications)
+	*e = TransactionClassification(value)
+	return err
+}
+
+// String returns the account type as returned by the API.
+func (e AccountType) String() string { return string(e) }
+
+// IsKnown returns whether the account type is one of the constants.
+func (e AccountType) IsKnown() bool { return isKnownEnum(string(e), accountTypes) }
+
+// MarshalJSON encodes the account type as a string.
+func (e AccountType) MarshalJSON() ([]byte, error) { return json.Marshal(string(e)) }
+
+// UnmarshalJSON decodes the account type, unknown values are preserved.
+func (e *AccountType) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, accountTypes)
+	*e = AccountType(value)
+	return err
+}
+
+// String returns the standing order status as returned by the API.
+func (e StandingOrderStatus) String() string { return string(e) }
+
+// IsKnown returns whether the standing order status is one of the constants.
+func (e StandingOrderStatus) IsKnown() bool { return isKnownEnum(string(e), standingOrderStatuses) }
+
+// MarshalJSON encodes the standing order status as a string.
+func (e StandingOrderStatus) MarshalJSON() ([]byte, error) { return json.Marshal(string(e)) }
+
+// UnmarshalJSON decodes the standing order status, unknown values are
+// preserved.
+func (e *StandingOrderStatus) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, standingOrderStatuses)
+	*e = StandingOrderStatus(value)
+	return err
+}
+
+// String returns the standing order frequency as returned by the API.
+func (e StandingOrderFrequency) String() string { return string(e) }
+
+// Kind returns the frequency without parameters e.g.
+// StandingOrderFrequencyIntervalWeekDay for "IntrvlWkDay:01:03".
+func (e StandingOrderFrequency) Kind() StandingOrderFrequency {
+	kind := strings.SplitN(string(e), ":", 2)[0]
+	return StandingOrderFrequency(kind)
+}
+
+// Params returns the parameters of the frequency e.g. ["01", "03"] for
+// "IntrvlWkDay:01:03".
+func (e StandingOrderFrequency) Params() []string {
+	parts := strings.Split(string(e), ":")
+	return parts[1:]
+}
+
+// IsKnown returns whether the kind of the standing order frequency is one of
+// the constants.
+func (e StandingOrderFrequency) IsKnown() bool {
+	return isKnownEnum(string(e.Kind()), standingOrderFrequencies)
+}
+
+// MarshalJSON encodes the standing order frequency as a string.
+func (e StandingOrderFrequency) MarshalJSON() ([]byte, error) { return json.Marshal(string(e)) }
+
+// UnmarshalJSON decodes the standing order frequency, unknown values are
+// preserved.
+func (e *StandingOrderFrequency) UnmarshalJSON(data []byte) error {
+	value := ""
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parts := strings.SplitN(value, ":", 2)
+	parts[0] = normaliseEnum(parts[0], standingOrderFrequencies)
+	*e = StandingOrderFrequency(strings.Join(parts, ":"))
+
+	return nil
+}
+
+// String returns the direct debit status as returned by the API.
+func (e DirectDebitStatus) String() string { return string(e) }
+
+// IsKnown returns whether the direct debit status is one of the constants.
+func (e DirectDebitStatus) IsKnown() bool { return isKnownEnum(string(e), directDebitStatuses) }
+
+// MarshalJSON encodes the direct debit status as a string.
+func (e DirectDebitStatus) MarshalJSON() ([]byte, error) { return json.Marshal(string(e)) }
+
+// UnmarshalJSON decodes the direct debit status, unknown values are
+// preserved.
+func (e *DirectDebitStatus) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data, directDebitStatuses)
+	*e = DirectDebitStatus(value)
+	return err
+}
+
+// unmarshalEnum decodes a JSON string, normalising it to one of the known
+// values.
+//
+// params
+//   - data - the JSON value
+//   - known - the known values
+//
+// returns
+//   - the normalised value
+//   - any errors that have occurred
+func unmarshalEnum(data []byte, known []string) (string, error) {
+	value := ""
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", err
+	}
+
+	return normaliseEnum(value, known), nil
+}
+
+// normaliseEnum matches a value to one of the known values ignoring case and
+// surrounding whitespace, values which do not match are returned unchanged.
+//
+// params
+//   - value - the value
+//   - known - the known values
+//
+// returns
+//   - the known value or the unchanged value
+func normaliseEnum(value string, known []string) string {
+	trimmed := strings.TrimSpace(value)
+
+	for _, k := range known {
+		if strings.EqualFold(trimmed, k) {
+			return k
+		}
+	}
+
+	return value
+}
+
+// isKnownEnum returns whether the value is one of the known values.
+//
+// params
+//   - value - the value
+//   - known - the known values
+//
+// returns
+//   - true if the value is known
+func isKnownEnum(value string, known []string) bool {
+	for _, k := range known {
+		if value == k {
+			return true
+		}
+	}
+
+	return false
+}
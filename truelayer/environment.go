@@ -0,0 +1,42 @@
+package truelayer
+
+// Environment is the set of hosts used by the TrueLayer client. Any host can
+// be overridden, e.g. to use a different region, to route through a proxy or to
+// point at a fake server in integration tests. Hosts may include a path prefix.
+type Environment struct {
+	// APIURL is the base URL of the Data API.
+	APIURL string
+	// AuthURL is the base URL of the auth server.
+	AuthURL string
+	// PaymentsURL is the base URL of the Payments API.
+	PaymentsURL string
+	// JWKSURL is the URL of the JWKS used to verify webhook signatures.
+	JWKSURL string
+}
+
+var (
+	// EnvironmentProduction is the TrueLayer live environment.
+	EnvironmentProduction = Environment{
+		APIURL:      "https://api.truelayer.com",
+		AuthURL:     "https://auth.truelayer.com",
+		PaymentsURL: "https://api.truelayer.com",
+		JWKSURL:     "https://webhooks.truelayer.com/.well-known/jwks",
+	}
+
+	// EnvironmentSandbox is the TrueLayer sandbox environment.
+	EnvironmentSandbox = Environment{
+		APIURL:      "https://api.truelayer-sandbox.com",
+		AuthURL:     "https://auth.truelayer-sandbox.com",
+		PaymentsURL: "https://api.truelayer-sandbox.com",
+		JWKSURL:     "https://webhooks.truelayer-sandbox.com/.well-known/jwks",
+	}
+)
+
+// Environment returns the environment the client is using, this can be used to
+// build requests to APIs not yet supported by the client.
+//
+// returns
+//   - the environment
+func (t *TrueLayer) Environment() Environment {
+	return t.environment
+}
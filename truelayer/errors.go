@@ -1,6 +1,11 @@
 package truelayer
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 type StrError string
 
@@ -8,18 +13,131 @@ func (str StrError) Error() string {
 	return string(str)
 }
 
+const (
+	HeaderRequestID     = "X-Request-Id"
+	HeaderCorrelationID = "Tl-Correlation-Id"
+
+	errorCodeInvalidGrant    = "invalid_grant"
+	errorCodeAccessDenied    = "access_denied"
+	errorCodeProviderError   = "provider_error"
+	errorCodeSCAExceeded     = "sca_exceeded"
+	errorCodeAccountNotFound = "account_not_found"
+	errorCodeRateLimited     = "rate_limit_exceeded"
+	errorCodeUnauthorized    = "unauthorized"
+
+	// Error categories, an ErrorResponse can be matched against these using
+	// errors.Is.
+	ErrInvalidGrant    = StrError("invalid_grant")
+	ErrAccessDenied    = StrError("access_denied")
+	ErrProviderError   = StrError("provider_error")
+	ErrSCAExceeded     = StrError("sca_exceeded")
+	ErrAccountNotFound = StrError("account_not_found")
+	ErrRateLimited     = StrError("rate_limited")
+	ErrUnauthorized    = StrError("unauthorized")
+)
+
 // ErrorResponse is a struct representation of the TrueLayer error response.
 // This is used when returning an error from an API call.
+//
+// ErrorResponse can be matched using errors.Is against the error categories
+// ErrInvalidGrant, ErrAccessDenied, ErrProviderError, ErrSCAExceeded,
+// ErrAccountNotFound, ErrRateLimited, ErrUnauthorized and ErrConsentExpired.
 type ErrorResponse struct {
 	ErrorMessage     string            `json:"error"`
 	ErrorDescription string            `json:"error_description"`
 	ErrorDetails     map[string]string `json:"error_details"`
+
+	// StatusCode is the HTTP status of the response, 0 when the error did not
+	// come from an HTTP response e.g. a failed webhook.
+	StatusCode int `json:"-"`
+	// RequestID is the request id of the failed request.
+	RequestID string `json:"-"`
+	// CorrelationID is the TrueLayer correlation id of the failed request.
+	CorrelationID string `json:"-"`
+	// Body is the raw response body, useful when the body is not JSON e.g.
+	// HTML error pages from a gateway.
+	Body []byte `json:"-"`
 }
 
 // Error is a method implemented by all `error` interfaces. Implementing this
 // means that er can treat ErrorResponse as an error.
-//
-// TODO: Return a better and more helpful error message.
 func (res *ErrorResponse) Error() string {
-	return fmt.Sprintf("%s: %s", res.ErrorMessage, res.ErrorDescription)
+	msg := res.ErrorMessage
+
+	if msg == "" {
+		msg = strings.ToLower(http.StatusText(res.StatusCode))
+	}
+
+	if msg == "" {
+		msg = "unknown error"
+	}
+
+	if res.ErrorDescription != "" {
+		msg = fmt.Sprintf("%s: %s", msg, res.ErrorDescription)
+	}
+
+	if res.StatusCode != 0 {
+		msg = fmt.Sprintf("%s (status %d)", msg, res.StatusCode)
+	}
+
+	if res.RequestID != "" {
+		msg = fmt.Sprintf("%s (request id %s)", msg, res.RequestID)
+	}
+
+	if res.CorrelationID != "" {
+		msg = fmt.Sprintf("%s (correlation id %s)", msg, res.CorrelationID)
+	}
+
+	return msg
+}
+
+// Is allows ErrorResponse to be matched against the error categories using
+// errors.Is. An ErrorResponse matches ErrConsentExpired when the provider's
+// consent has lapsed and the user must re-authenticate.
+//
+// params
+//   - target - the error to compare against
+//
+// returns
+//   - true if the ErrorResponse matches the target
+func (res *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrInvalidGrant:
+		return res.ErrorMessage == errorCodeInvalidGrant
+	case ErrAccessDenied:
+		return res.ErrorMessage == errorCodeAccessDenied
+	case ErrProviderError:
+		return res.ErrorMessage == errorCodeProviderError
+	case ErrSCAExceeded, ErrConsentExpired:
+		return res.ErrorMessage == errorCodeSCAExceeded
+	case ErrAccountNotFound:
+		return res.ErrorMessage == errorCodeAccountNotFound
+	case ErrRateLimited:
+		return res.ErrorMessage == errorCodeRateLimited || res.StatusCode == http.StatusTooManyRequests
+	case ErrUnauthorized:
+		return res.ErrorMessage == errorCodeUnauthorized || res.StatusCode == http.StatusUnauthorized
+	}
+
+	return false
+}
+
+// Retryable returns whether the request that caused the error can be retried
+// later, this is true for rate limiting, provider errors and server errors.
+//
+// returns
+//   - true if the request can be retried
+func (res *ErrorResponse) Retryable() bool {
+	return res.Is(ErrRateLimited) || res.Is(ErrProviderError) || res.StatusCode >= 500
+}
+
+// IsRetryable returns whether err is an ErrorResponse which can be retried.
+//
+// params
+//   - err - the error to check
+//
+// returns
+//   - true if the error can be retried
+func IsRetryable(err error) bool {
+	respErr := &ErrorResponse{}
+	return errors.As(err, &respErr) && respErr.Retryable()
 }
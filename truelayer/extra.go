@@ -0,0 +1,214 @@
+package truelayer
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// modelFields caches the JSON field names of each model type.
+var modelFields sync.Map
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// unmarshalModel decodes data into v, which must be a pointer to a struct
+// type without an UnmarshalJSON method, usually an alias of the model. Fields
+// of data which are not decoded into v are set in extra. The currency field of
+// data is set on the provided amounts before v is decoded so that they can be
+// decoded exactly.
+//
+// params
+//   - data - the JSON to decode
+//   - v - the struct to decode into
+//   - extra - where to set the unrecognised fields
+//   - amounts - amounts in the currency of the model (optional)
+//
+// returns
+//   - any errors that have occurred
+func unmarshalModel(data []byte, v interface{}, extra *map[string]json.RawMessage, amounts ...*Money) error {
+	fields := map[string]json.RawMessage{}
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if len(amounts) > 0 {
+		currency := ""
+
+		if raw, ok := fields["currency"]; ok {
+			if err := json.Unmarshal(raw, &currency); err != nil {
+				return err
+			}
+		}
+
+		for _, amount := range amounts {
+			amount.Currency = currency
+		}
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+
+	for name := range fields {
+		if known[strings.ToLower(name)] {
+			delete(fields, name)
+		}
+	}
+
+	*extra = nil
+
+	if len(fields) > 0 {
+		*extra = fields
+	}
+
+	return nil
+}
+
+// decodeJSON decodes the next JSON value from r into v. When the client keeps
+// raw JSON, the Raw field of every model within v is set to the JSON it was
+// decoded from.
+//
+// params
+//   - r - the reader to decode from
+//   - v - pointer to the value to decode into
+//
+// returns
+//   - any errors that have occurred
+func (t *TrueLayer) decodeJSON(r io.Reader, v interface{}) error {
+	if !t.rawJSON {
+		return json.NewDecoder(r).Decode(v)
+	}
+
+	data := json.RawMessage{}
+
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	setRaw(reflect.ValueOf(v), data)
+
+	return nil
+}
+
+// setRaw walks a decoded value alongside the JSON it was decoded from, setting
+// the Raw field of every struct which has one.
+//
+// params
+//   - v - the decoded value
+//   - data - the JSON v was decoded from
+func setRaw(v reflect.Value, data json.RawMessage) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			setRaw(v.Elem(), data)
+		}
+	case reflect.Slice:
+		items := []json.RawMessage{}
+
+		if json.Unmarshal(data, &items) != nil {
+			return
+		}
+
+		for i := 0; i < len(items) && i < v.Len(); i++ {
+			setRaw(v.Index(i), items[i])
+		}
+	case reflect.Struct:
+		fields := map[string]json.RawMessage{}
+
+		if json.Unmarshal(data, &fields) != nil {
+			return
+		}
+
+		lower := make(map[string]json.RawMessage, len(fields))
+
+		for name, raw := range fields {
+			lower[strings.ToLower(name)] = raw
+		}
+
+		setStructRaw(v, data, lower)
+	}
+}
+
+// setStructRaw sets the Raw field of a struct and of the models within its
+// fields, embedded structs share the struct's JSON.
+//
+// params
+//   - v - the decoded struct
+//   - data - the JSON v was decoded from
+//   - fields - the JSON fields of data keyed by their lower case name
+func setStructRaw(v reflect.Value, data json.RawMessage, fields map[string]json.RawMessage) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		tag := field.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+
+		switch {
+		case field.PkgPath != "" && !field.Anonymous:
+		case field.Name == "Raw" && field.Type == rawMessageType:
+			v.Field(i).Set(reflect.ValueOf(data))
+		case field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct:
+			setStructRaw(v.Field(i), data, fields)
+		case tag != "-":
+			if name == "" {
+				name = field.Name
+			}
+
+			if raw, ok := fields[strings.ToLower(name)]; ok {
+				setRaw(v.Field(i), raw)
+			}
+		}
+	}
+}
+
+// jsonFieldNames returns the lower case JSON names of the fields of a struct
+// type, including the fields of embedded structs.
+//
+// params
+//   - t - the struct type
+//
+// returns
+//   - set of the lower case field names
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if names, ok := modelFields.Load(t); ok {
+		return names.(map[string]bool)
+	}
+
+	names := map[string]bool{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+
+		if tag == "-" || (field.PkgPath != "" && !field.Anonymous) {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for embedded := range jsonFieldNames(field.Type) {
+				names[embedded] = true
+			}
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		names[strings.ToLower(name)] = true
+	}
+
+	modelFields.Store(t, names)
+
+	return names
+}
@@ -0,0 +1,180 @@
+package truelayer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	EndpointDataV1Info = "/data/v1/info"
+)
+
+type Info struct {
+	UpdateTimestamp time.Time     `json:"update_timestamp"`
+	FullName        string        `json:"full_name"`
+	DateOfBirth     Timestamp     `json:"date_of_birth"`
+	Emails          []string      `json:"emails"`
+	Phones          []string      `json:"phones"`
+	Addresses       []InfoAddress `json:"addresses"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+	// Raw is the JSON the info was decoded from, only set when the client was
+	// created with WithRawJSON.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the info, keeping any fields which are not modelled.
+func (i *Info) UnmarshalJSON(data []byte) error {
+	type alias Info
+	return unmarshalModel(data, (*alias)(i), &i.Extra)
+}
+
+type InfoAddress struct {
+	Address string `json:"address"`
+	City    string `json:"city"`
+	State   string `json:"state"`
+	Zip     string `json:"zip"`
+	Country string `json:"country"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the address, keeping any fields which are not
+// modelled.
+func (a *InfoAddress) UnmarshalJSON(data []byte) error {
+	type alias InfoAddress
+	return unmarshalModel(data, (*alias)(a), &a.Extra)
+}
+
+// GetInfo retrieves the identity information of the account holder associated
+// with the provided access token. A provider can return more than one result
+// when a connection has multiple account holders.
+//
+// params
+//   - accessToken - access token to get the info from
+//
+// returns
+//   - list of account holder info
+//   - errors from the api request
+func (t *TrueLayer) GetInfo(accessToken string) ([]Info, error) {
+	return t.GetInfoWithContext(context.Background(), accessToken)
+}
+
+// GetInfoWithContext is the same as GetInfo but uses the provided context for
+// the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//
+// returns
+//   - list of account holder info
+//   - errors from the api request
+func (t *TrueLayer) GetInfoWithContext(ctx context.Context, accessToken string) ([]Info, error) {
+	u, err := buildURL(t.getBaseURL(), EndpointDataV1Info)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.doAuthorizedGetRequest(ctx, u, accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, parseErrorResponse(res)
+	}
+
+	infoResp := InfoResponse{}
+	err = t.decodeJSON(res.Body, &infoResp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return infoResp.Results, nil
+}
+
+// GetInfoWithTokenSource is the same as GetInfoWithContext but takes the access
+// token from the provided TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//
+// returns
+//   - list of account holder info
+//   - errors from the api request
+func (t *TrueLayer) GetInfoWithTokenSource(ctx context.Context, source *TokenSource) ([]Info, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetInfoWithContext(ctx, accessToken)
+}
+
+// GetInfoAsync triggers an async request to TrueLayer to get the identity
+// information of the account holder associated to the access token.
+//
+// params
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetInfoAsync(accessToken string, webhookURI string) (*AsyncRequestResponse, error) {
+	return t.GetInfoAsyncWithContext(context.Background(), accessToken, webhookURI)
+}
+
+// GetInfoAsyncWithContext is the same as GetInfoAsync but uses the provided
+// context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the info from
+//   - webhookURI - uri to access upon async job completion
+//
+// returns
+//   - truelayer response
+//   - errors from the api request
+func (t *TrueLayer) GetInfoAsyncWithContext(ctx context.Context, accessToken string, webhookURI string) (*AsyncRequestResponse, error) {
+	return t.doAsyncAccountRequest(ctx, EndpointDataV1Info, accessToken, webhookURI, nil)
+}
+
+// GetInfoAsyncRequest takes the result from a Webhook request and sends a
+// request to the correct endpoint to fetch the info.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetInfoAsyncRequest(accessToken string, webhook *WebhookRequest) ([]Info, error) {
+	return t.GetInfoAsyncRequestWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetInfoAsyncRequestWithContext is the same as GetInfoAsyncRequest but uses
+// the provided context for the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+func (t *TrueLayer) GetInfoAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]Info, error) {
+	resp := InfoResponse{}
+	err := t.GetAsyncRequestResultWithContext(ctx, accessToken, webhook, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
+}
@@ -0,0 +1,159 @@
+package truelayer
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Client is the interface implemented by the TrueLayer client, it can be used
+// in place of *TrueLayer so that the client can be mocked.
+type Client interface {
+	HandleAsyncWebhookRequest(req *http.Request) (*WebhookRequest, error)
+	HandleAsyncWebhookRequestBody(body io.ReadCloser) (*WebhookRequest, error)
+	GetAsyncRequestResult(accessToken string, webhook *WebhookRequest, v interface{}) error
+	GetAsyncRequestResultWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest, v interface{}) error
+
+	Environment() Environment
+
+	GetAuthenticationLink(providers []string, permissions []string, redirURI *url.URL, postCode bool) (link string, err error)
+	GetSecureAuthenticationLink(providers []string, permissions []string, redirURI *url.URL, postCode bool) (*AuthenticationLink, error)
+	GetAccessToken(code string, redirURI *url.URL, opts *AccessTokenOptions) (token *AccessTokenResponse, err error)
+	GetAccessTokenWithContext(ctx context.Context, code string, redirURI *url.URL, opts *AccessTokenOptions) (token *AccessTokenResponse, err error)
+	RefreshAccessToken(refreshToken string) (token *AccessTokenResponse, err error)
+	RefreshAccessTokenWithContext(ctx context.Context, refreshToken string) (token *AccessTokenResponse, err error)
+	GetClientCredentialsToken(scopes []string) (token *Token, err error)
+	GetClientCredentialsTokenWithContext(ctx context.Context, scopes []string) (token *Token, err error)
+	DeleteConnection(accessToken string) error
+	DeleteConnectionWithContext(ctx context.Context, accessToken string) error
+	DeleteConnectionWithTokenSource(ctx context.Context, source *TokenSource) error
+
+	GetAuthenticationLinkWithOptions(opts *AuthLinkOptions) (link string, err error)
+	GetSecureAuthenticationLinkWithOptions(opts *AuthLinkOptions) (*AuthenticationLink, error)
+
+	ExtendConnection(refreshToken string, redirURI *url.URL, userHasReconfirmedConsent bool) (*ExtendConnectionResponse, error)
+	ExtendConnectionWithContext(ctx context.Context, refreshToken string, redirURI *url.URL, userHasReconfirmedConsent bool) (*ExtendConnectionResponse, error)
+	GetReauthenticationLink(refreshToken string, redirURI *url.URL) (link string, err error)
+	GetReauthenticationLinkWithContext(ctx context.Context, refreshToken string, redirURI *url.URL) (link string, err error)
+
+	NewTokenSource(store TokenStore, key string) *TokenSource
+
+	GetAccounts(accessToken string) ([]Account, error)
+	GetAccountsWithContext(ctx context.Context, accessToken string) ([]Account, error)
+	GetAccountsWithTokenSource(ctx context.Context, source *TokenSource) ([]Account, error)
+	GetAccountsAsync(accessToken string, webhookURI string) (*AsyncRequestResponse, error)
+	GetAccountsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string) (*AsyncRequestResponse, error)
+	GetAccountsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]Account, error)
+	GetAccountsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]Account, error)
+	GetAccount(accessToken string, accountID string) (*Account, error)
+	GetAccountWithContext(ctx context.Context, accessToken string, accountID string) (*Account, error)
+	GetAccountWithTokenSource(ctx context.Context, source *TokenSource, accountID string) (*Account, error)
+	GetAccountAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetAccountAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetAccountAsyncRequest(accessToken string, webhook *WebhookRequest) (*Account, error)
+	GetAccountAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*Account, error)
+	GetAccountBalance(accessToken string, accountID string) (*AccountBalance, error)
+	GetAccountBalanceWithContext(ctx context.Context, accessToken string, accountID string) (*AccountBalance, error)
+	GetAccountBalanceWithTokenSource(ctx context.Context, source *TokenSource, accountID string) (*AccountBalance, error)
+	GetAccountBalanceAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetAccountBalanceAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetAccountBalanceAsyncRequest(accessToken string, webhook *WebhookRequest) (*AccountBalance, error)
+	GetAccountBalanceAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*AccountBalance, error)
+	GetAccountTransactions(accessToken string, accountID string, opts *AccountOptions) ([]AccountTransaction, error)
+	GetAccountTransactionsWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) ([]AccountTransaction, error)
+	GetAccountTransactionsWithTokenSource(ctx context.Context, source *TokenSource, accountID string, opts *AccountOptions) ([]AccountTransaction, error)
+	GetAccountTransactionsAsync(accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error)
+	GetAccountTransactionsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error)
+	GetAccountTransactionsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]AccountTransaction, error)
+	GetAccountTransactionsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]AccountTransaction, error)
+	GetAccountPendingTransactions(accessToken string, accountID string, opts *AccountOptions) ([]AccountTransaction, error)
+	GetAccountPendingTransactionsWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) ([]AccountTransaction, error)
+	GetAccountPendingTransactionsWithTokenSource(ctx context.Context, source *TokenSource, accountID string, opts *AccountOptions) ([]AccountTransaction, error)
+	GetAccountPendingTransactionsAsync(accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error)
+	GetAccountPendingTransactionsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error)
+	GetAccountPendingTransactionsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]AccountTransaction, error)
+	GetAccountPendingTransactionsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]AccountTransaction, error)
+	GetAccountStandingOrders(accessToken string, accountID string) ([]AccountStandingOrder, error)
+	GetAccountStandingOrdersWithContext(ctx context.Context, accessToken string, accountID string) ([]AccountStandingOrder, error)
+	GetAccountStandingOrdersWithTokenSource(ctx context.Context, source *TokenSource, accountID string) ([]AccountStandingOrder, error)
+	GetAccountStandingOrdersAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetAccountStandingOrdersAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetAccountStandingOrdersAsyncRequest(accessToken string, webhook *WebhookRequest) ([]AccountStandingOrder, error)
+	GetAccountStandingOrdersAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]AccountStandingOrder, error)
+	GetAccountDirectDebits(accessToken string, accountID string) ([]AccountDirectDebit, error)
+	GetAccountDirectDebitsWithContext(ctx context.Context, accessToken string, accountID string) ([]AccountDirectDebit, error)
+	GetAccountDirectDebitsWithTokenSource(ctx context.Context, source *TokenSource, accountID string) ([]AccountDirectDebit, error)
+	GetAccountDirectDebitsAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetAccountDirectDebitsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetAccountDirectDebitsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]AccountDirectDebit, error)
+	GetAccountDirectDebitsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]AccountDirectDebit, error)
+
+	GetCards(accessToken string) ([]Card, error)
+	GetCardsWithContext(ctx context.Context, accessToken string) ([]Card, error)
+	GetCardsWithTokenSource(ctx context.Context, source *TokenSource) ([]Card, error)
+	GetCardsAsync(accessToken string, webhookURI string) (*AsyncRequestResponse, error)
+	GetCardsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string) (*AsyncRequestResponse, error)
+	GetCardsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]Card, error)
+	GetCardsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]Card, error)
+	GetCard(accessToken string, accountID string) (*Card, error)
+	GetCardWithContext(ctx context.Context, accessToken string, accountID string) (*Card, error)
+	GetCardWithTokenSource(ctx context.Context, source *TokenSource, accountID string) (*Card, error)
+	GetCardAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetCardAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetCardAsyncRequest(accessToken string, webhook *WebhookRequest) (*Card, error)
+	GetCardAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*Card, error)
+	GetCardBalance(accessToken string, accountID string) (*CardBalance, error)
+	GetCardBalanceWithContext(ctx context.Context, accessToken string, accountID string) (*CardBalance, error)
+	GetCardBalanceWithTokenSource(ctx context.Context, source *TokenSource, accountID string) (*CardBalance, error)
+	GetCardBalanceAsync(accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetCardBalanceAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string) (*AsyncRequestResponse, error)
+	GetCardBalanceAsyncRequest(accessToken string, webhook *WebhookRequest) (*CardBalance, error)
+	GetCardBalanceAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*CardBalance, error)
+	GetCardTransactions(accessToken string, accountID string, opts *AccountOptions) ([]CardTransaction, error)
+	GetCardTransactionsWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) ([]CardTransaction, error)
+	GetCardTransactionsWithTokenSource(ctx context.Context, source *TokenSource, accountID string, opts *AccountOptions) ([]CardTransaction, error)
+	GetCardTransactionsAsync(accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error)
+	GetCardTransactionsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error)
+	GetCardTransactionsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]CardTransaction, error)
+	GetCardTransactionsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]CardTransaction, error)
+	GetCardPendingTransactions(accessToken string, accountID string, opts *AccountOptions) ([]CardTransaction, error)
+	GetCardPendingTransactionsWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) ([]CardTransaction, error)
+	GetCardPendingTransactionsWithTokenSource(ctx context.Context, source *TokenSource, accountID string, opts *AccountOptions) ([]CardTransaction, error)
+	GetCardPendingTransactionsAsync(accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error)
+	GetCardPendingTransactionsAsyncWithContext(ctx context.Context, accessToken string, webhookURI string, accountID string, opts *AccountOptions) (*AsyncRequestResponse, error)
+	GetCardPendingTransactionsAsyncRequest(accessToken string, webhook *WebhookRequest) ([]CardTransaction, error)
+	GetCardPendingTransactionsAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]CardTransaction, error)
+
+	GetInfo(accessToken string) ([]Info, error)
+	GetInfoWithContext(ctx context.Context, accessToken string) ([]Info, error)
+	GetInfoWithTokenSource(ctx context.Context, source *TokenSource) ([]Info, error)
+	GetInfoAsync(accessToken string, webhookURI string) (*AsyncRequestResponse, error)
+	GetInfoAsyncWithContext(ctx context.Context, accessToken string, webhookURI string) (*AsyncRequestResponse, error)
+	GetInfoAsyncRequest(accessToken string, webhook *WebhookRequest) ([]Info, error)
+	GetInfoAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) ([]Info, error)
+
+	GetMe(accessToken string) (*Me, error)
+	GetMeWithContext(ctx context.Context, accessToken string) (*Me, error)
+	GetMeWithTokenSource(ctx context.Context, source *TokenSource) (*Me, error)
+
+	NewBatch() *Batch
+	GetBatchAsyncRequest(accessToken string, webhook *WebhookRequest) (*BatchResult, error)
+	GetBatchAsyncRequestWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*BatchResult, error)
+
+	PollAsyncRequest(accessToken string, async *AsyncRequestResponse, v interface{}, opts *PollOptions) error
+	PollAsyncRequestWithContext(ctx context.Context, accessToken string, async *AsyncRequestResponse, v interface{}, opts *PollOptions) error
+
+	GetAccountTransactionsIterator(accessToken string, accountID string, opts *AccountOptions) (*TransactionIterator, error)
+	GetAccountTransactionsIteratorWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) (*TransactionIterator, error)
+	GetAccountPendingTransactionsIterator(accessToken string, accountID string, opts *AccountOptions) (*TransactionIterator, error)
+	GetAccountPendingTransactionsIteratorWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) (*TransactionIterator, error)
+	GetAccountTransactionsAsyncRequestIterator(accessToken string, webhook *WebhookRequest) (*TransactionIterator, error)
+	GetAccountTransactionsAsyncRequestIteratorWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*TransactionIterator, error)
+
+	NewWebhookHandler(onSuccess func(req *WebhookRequest), onFailure func(req *WebhookRequest, err error)) *WebhookHandler
+
+	NewWebhookVerifier() *WebhookVerifier
+}
+
+var _ Client = (*TrueLayer)(nil)
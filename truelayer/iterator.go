@@ -0,0 +1,418 @@
+package truelayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	ErrIteratorMalformed = StrError("transactions response is not a JSON object")
+)
+
+// TransactionIterator streams transactions from a response one at a time
+// without holding the whole response in memory. It must be closed once done
+// with, closing it early stops reading the response.
+//
+//	it, err := t.GetAccountTransactionsIterator(accessToken, accountID, nil)
+//	if err != nil {
+//		return err
+//	}
+//	defer it.Close()
+//
+//	for it.Next() {
+//		tx := it.Transaction()
+//	}
+//
+//	return it.Err()
+type TransactionIterator struct {
+	body   io.ReadCloser
+	dec    *json.Decoder
+	status asyncResultStatus
+	raw    bool
+	tx     AccountTransaction
+	err    error
+	done   bool
+}
+
+// GetAccountTransactionsIterator is the same as GetAccountTransactions but
+// streams the transactions rather than decoding them into a slice.
+//
+// params
+//   - accessToken - access token to get the account from
+//   - accountID - the account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions iterator
+//   - errors from the api request
+func (t *TrueLayer) GetAccountTransactionsIterator(accessToken string, accountID string, opts *AccountOptions) (*TransactionIterator, error) {
+	return t.GetAccountTransactionsIteratorWithContext(context.Background(), accessToken, accountID, opts)
+}
+
+// GetAccountTransactionsIteratorWithContext is the same as
+// GetAccountTransactionsIterator but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the account from
+//   - accountID - the account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions iterator
+//   - errors from the api request
+func (t *TrueLayer) GetAccountTransactionsIteratorWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) (*TransactionIterator, error) {
+	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1AccountTransactions, accountID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.doAccountTransactionsRequest(ctx, u, accessToken, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransactionIterator(res.Body, t.rawJSON)
+}
+
+// GetAccountPendingTransactionsIterator is the same as
+// GetAccountPendingTransactions but streams the transactions rather than
+// decoding them into a slice.
+//
+// params
+//   - accessToken - access token to get the account from
+//   - accountID - the account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions iterator
+//   - errors from the api request
+func (t *TrueLayer) GetAccountPendingTransactionsIterator(accessToken string, accountID string, opts *AccountOptions) (*TransactionIterator, error) {
+	return t.GetAccountPendingTransactionsIteratorWithContext(context.Background(), accessToken, accountID, opts)
+}
+
+// GetAccountPendingTransactionsIteratorWithContext is the same as
+// GetAccountPendingTransactionsIterator but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the account from
+//   - accountID - the account ID to get
+//   - opts - options for the request
+//
+// returns
+//   - the transactions iterator
+//   - errors from the api request
+func (t *TrueLayer) GetAccountPendingTransactionsIteratorWithContext(ctx context.Context, accessToken string, accountID string, opts *AccountOptions) (*TransactionIterator, error) {
+	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1AccountPendingTransactions, accountID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.doAccountTransactionsRequest(ctx, u, accessToken, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransactionIterator(res.Body, t.rawJSON)
+}
+
+// GetAccountTransactionsAsyncRequestIterator is the same as
+// GetAccountTransactionsAsyncRequest but streams the transactions rather than
+// decoding them into a slice. This can also be used for the results of
+// GetAccountPendingTransactionsAsync.
+//
+// params
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+//
+// returns
+//   - the transactions iterator
+//   - errors from the api request, ErrAsyncRequestNotComplete if the request
+//     has not completed
+func (t *TrueLayer) GetAccountTransactionsAsyncRequestIterator(accessToken string, webhook *WebhookRequest) (*TransactionIterator, error) {
+	return t.GetAccountTransactionsAsyncRequestIteratorWithContext(context.Background(), accessToken, webhook)
+}
+
+// GetAccountTransactionsAsyncRequestIteratorWithContext is the same as
+// GetAccountTransactionsAsyncRequestIterator but uses the provided context for
+// the underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token associated to the webhook request
+//   - webhook - the webhook request to fetch data from
+//
+// returns
+//   - the transactions iterator
+//   - errors from the api request, ErrAsyncRequestNotComplete if the request
+//     has not completed
+func (t *TrueLayer) GetAccountTransactionsAsyncRequestIteratorWithContext(ctx context.Context, accessToken string, webhook *WebhookRequest) (*TransactionIterator, error) {
+	if webhook == nil {
+		return nil, ErrWebhookRequestNil
+	}
+
+	res, err := t.doAsyncResultRequest(ctx, accessToken, webhook.TaskID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusAccepted {
+		res.Body.Close()
+		return nil, ErrAsyncRequestNotComplete
+	}
+
+	return newTransactionIterator(res.Body, t.rawJSON)
+}
+
+// newTransactionIterator creates an iterator over the results of a response
+// body, reading up to the first transaction. The body is closed if an error
+// is returned.
+//
+// params
+//   - body - the response body
+//   - raw - set the Raw field of each transaction
+//
+// returns
+//   - the transactions iterator
+//   - any errors that have occurred including failed async requests
+func newTransactionIterator(body io.ReadCloser, raw bool) (*TransactionIterator, error) {
+	it := &TransactionIterator{
+		body: body,
+		dec:  json.NewDecoder(body),
+		raw:  raw,
+	}
+
+	tok, err := it.dec.Token()
+
+	if err != nil {
+		it.Close()
+		return nil, err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		it.Close()
+		return nil, ErrIteratorMalformed
+	}
+
+	found, err := it.seekResults()
+
+	if err == nil && !found {
+		it.done = true
+		err = it.finish()
+	}
+
+	if err != nil {
+		it.Close()
+		return nil, err
+	}
+
+	return it, nil
+}
+
+// decodeTransaction decodes the next transaction, keeping its JSON when raw
+// JSON is enabled.
+//
+// returns
+//   - any errors that have occurred
+func (it *TransactionIterator) decodeTransaction() error {
+	if !it.raw {
+		return it.dec.Decode(&it.tx)
+	}
+
+	data := json.RawMessage{}
+
+	if err := it.dec.Decode(&data); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, &it.tx); err != nil {
+		return err
+	}
+
+	it.tx.Raw = data
+
+	return nil
+}
+
+// Next decodes the next transaction, returning false once there are no more
+// transactions or an error has occurred. The response is closed once all
+// transactions have been read.
+//
+// returns
+//   - true if a transaction was decoded
+func (it *TransactionIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	if it.dec.More() {
+		it.tx = AccountTransaction{}
+		it.err = it.decodeTransaction()
+
+		if it.err != nil {
+			it.Close()
+			return false
+		}
+
+		return true
+	}
+
+	it.done = true
+
+	if _, err := it.dec.Token(); err != nil {
+		it.err = err
+	} else {
+		it.err = it.finish()
+	}
+
+	it.Close()
+
+	return false
+}
+
+// Transaction returns the transaction decoded by the last call to Next.
+//
+// returns
+//   - the transaction
+func (it *TransactionIterator) Transaction() AccountTransaction {
+	return it.tx
+}
+
+// Err returns the error which stopped the iteration, if any.
+//
+// returns
+//   - any errors that have occurred
+func (it *TransactionIterator) Err() error {
+	return it.err
+}
+
+// Close closes the response, stopping the iteration. It is safe to call Close
+// more than once.
+//
+// returns
+//   - any errors that have occurred
+func (it *TransactionIterator) Close() error {
+	it.done = true
+
+	if it.body == nil {
+		return nil
+	}
+
+	err := it.body.Close()
+	it.body = nil
+
+	return err
+}
+
+// seekResults reads the fields of the response up to the start of the results
+// array, keeping the async status fields.
+//
+// returns
+//   - true if the results array was found, false if the end of the response
+//     was reached
+//   - any errors that have occurred
+func (it *TransactionIterator) seekResults() (bool, error) {
+	for it.dec.More() {
+		tok, err := it.dec.Token()
+
+		if err != nil {
+			return false, err
+		}
+
+		key, _ := tok.(string)
+
+		if key != "results" {
+			err = it.decodeField(key)
+
+			if err != nil {
+				return false, err
+			}
+
+			continue
+		}
+
+		tok, err = it.dec.Token()
+
+		if err != nil {
+			return false, err
+		}
+
+		if tok == nil {
+			continue
+		}
+
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return false, ErrIteratorMalformed
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// finish reads the remaining fields of the response after the results and
+// checks the async status.
+//
+// returns
+//   - any errors that have occurred including failed async requests
+func (it *TransactionIterator) finish() error {
+	for it.dec.More() {
+		tok, err := it.dec.Token()
+
+		if err != nil {
+			return err
+		}
+
+		key, _ := tok.(string)
+		err = it.decodeField(key)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	switch it.status.Status {
+	case AsyncStatusQueued, AsyncStatusRunning:
+		return ErrAsyncRequestNotComplete
+	case AsyncStatusFailed:
+		return &ErrorResponse{
+			ErrorMessage:     it.status.Error,
+			ErrorDescription: it.status.ErrorDescription,
+		}
+	}
+
+	return nil
+}
+
+// decodeField decodes the value of a field other than the results, keeping
+// the async status fields and skipping any others.
+//
+// params
+//   - key - the field name
+//
+// returns
+//   - any errors that have occurred
+func (it *TransactionIterator) decodeField(key string) error {
+	switch key {
+	case "status":
+		return it.dec.Decode(&it.status.Status)
+	case "error":
+		return it.dec.Decode(&it.status.Error)
+	case "error_description":
+		return it.dec.Decode(&it.status.ErrorDescription)
+	}
+
+	return it.dec.Decode(&json.RawMessage{})
+}
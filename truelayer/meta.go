@@ -0,0 +1,112 @@
+package truelayer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	EndpointDataV1Me = "/data/v1/me"
+)
+
+type Me struct {
+	ClientID               string    `json:"client_id"`
+	CredentialsID          string    `json:"credentials_id"`
+	ConsentStatus          string    `json:"consent_status"`
+	ConsentStatusUpdatedAt time.Time `json:"consent_status_updated_at"`
+	ConsentCreatedAt       time.Time `json:"consent_created_at"`
+	ConsentExpiresAt       time.Time `json:"consent_expires_at"`
+	Provider               Provider  `json:"provider"`
+	Scopes                 []string  `json:"scopes"`
+
+	// Extra holds fields returned by the API which are not modelled.
+	Extra map[string]json.RawMessage `json:"-"`
+	// Raw is the JSON the metadata was decoded from, only set when the client was
+	// created with WithRawJSON.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the metadata, keeping any fields which are not
+// modelled.
+func (m *Me) UnmarshalJSON(data []byte) error {
+	type alias Me
+	return unmarshalModel(data, (*alias)(m), &m.Extra)
+}
+
+// GetMe retrieves the connection metadata associated with the provided access
+// token. This includes the credentials ID, the provider the connection belongs
+// to and when consent was granted and expires.
+//
+// params
+//   - accessToken - access token to get the metadata for
+//
+// returns
+//   - the connection metadata
+//   - errors from the api request
+func (t *TrueLayer) GetMe(accessToken string) (*Me, error) {
+	return t.GetMeWithContext(context.Background(), accessToken)
+}
+
+// GetMeWithContext is the same as GetMe but uses the provided context for the
+// underlying request.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - access token to get the metadata for
+//
+// returns
+//   - the connection metadata
+//   - errors from the api request
+func (t *TrueLayer) GetMeWithContext(ctx context.Context, accessToken string) (*Me, error) {
+	u, err := buildURL(t.getBaseURL(), EndpointDataV1Me)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.doAuthorizedGetRequest(ctx, u, accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return nil, parseErrorResponse(res)
+	}
+
+	meResp := MeResponse{}
+	err = t.decodeJSON(res.Body, &meResp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(meResp.Results) == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &meResp.Results[0], nil
+}
+
+// GetMeWithTokenSource is the same as GetMeWithContext but takes the access
+// token from the provided TokenSource, refreshing it if required.
+//
+// params
+//   - ctx - context for the request
+//   - source - token source of the connection
+//
+// returns
+//   - the connection metadata
+//   - errors from the api request
+func (t *TrueLayer) GetMeWithTokenSource(ctx context.Context, source *TokenSource) (*Me, error) {
+	accessToken, err := source.AccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetMeWithContext(ctx, accessToken)
+}
@@ -0,0 +1,392 @@
+package truelayer
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	ErrCurrencyMismatch = StrError("money currencies do not match")
+	ErrMoneyInvalid     = StrError("money amount is not a valid decimal")
+	ErrMoneyPrecision   = StrError("money amount has more decimal places than its currency")
+	ErrMoneyOverflow    = StrError("money amount is out of range")
+)
+
+// decimalPattern matches a plain decimal literal, fractions and exponents
+// are not accepted as they can't be written back as a decimal.
+var decimalPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// currencyExponents holds the number of minor unit digits of ISO 4217
+// currencies which do not use 2.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// Money is an exact amount of a currency, stored as an integer number of the
+// currency's minor units e.g. pence for GBP. Amounts are decoded from the
+// API's decimal literals without going through float64 so they can be summed
+// without drift.
+//
+// Some providers return amounts with more decimal places than the currency
+// has, these are rounded to the nearest minor unit and the original decimal is
+// kept in Exact.
+type Money struct {
+	// Amount is the amount in minor units of Currency.
+	Amount int64
+	// Currency is the ISO 4217 currency code.
+	Currency string
+	// Exact is the original decimal amount when it had more decimal places
+	// than Currency and Amount was rounded, otherwise empty. Arithmetic uses
+	// Amount and does not keep Exact.
+	Exact string
+}
+
+// NewMoney creates Money from an amount in minor units.
+//
+// params
+//   - minor - the amount in minor units e.g. pence
+//   - currency - ISO 4217 currency code
+//
+// returns
+//   - the money
+func NewMoney(minor int64, currency string) Money {
+	return Money{Amount: minor, Currency: currency}
+}
+
+// ParseMoney parses a plain decimal string e.g. "-12.34" into Money. The
+// amount must not have more decimal places than the currency's minor units.
+//
+// params
+//   - amount - the decimal amount
+//   - currency - ISO 4217 currency code
+//
+// returns
+//   - the money
+//   - any errors that have occurred
+func ParseMoney(amount, currency string) (Money, error) {
+	money, err := parseMoney(amount, currency)
+
+	if err != nil {
+		return Money{}, err
+	}
+
+	if money.IsRounded() {
+		return Money{}, ErrMoneyPrecision
+	}
+
+	return money, nil
+}
+
+// parseMoney parses a decimal string into Money, rounding half away from zero
+// to the currency's minor units and keeping the original decimal in Exact when
+// it has more decimal places than the currency.
+//
+// params
+//   - amount - the decimal amount
+//   - currency - ISO 4217 currency code
+//
+// returns
+//   - the money
+//   - any errors that have occurred
+func parseMoney(amount, currency string) (Money, error) {
+	amount = strings.TrimSpace(amount)
+
+	if !decimalPattern.MatchString(amount) {
+		return Money{}, ErrMoneyInvalid
+	}
+
+	r, ok := new(big.Rat).SetString(amount)
+
+	if !ok {
+		return Money{}, ErrMoneyInvalid
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(pow10(CurrencyExponent(currency))))
+
+	money := Money{Currency: currency}
+	minor := new(big.Int).Set(r.Num())
+
+	if !r.IsInt() {
+		rem := new(big.Int)
+		minor.QuoRem(new(big.Int).Abs(r.Num()), r.Denom(), rem)
+
+		if rem.Lsh(rem, 1).Cmp(r.Denom()) >= 0 {
+			minor.Add(minor, big.NewInt(1))
+		}
+
+		if r.Sign() < 0 {
+			minor.Neg(minor)
+		}
+
+		money.Exact = amount
+	}
+
+	if !minor.IsInt64() {
+		return Money{}, ErrMoneyOverflow
+	}
+
+	money.Amount = minor.Int64()
+
+	return money, nil
+}
+
+// MoneyFromFloat converts a float64 amount in major units to Money, rounding
+// to the nearest minor unit. This is provided for callers migrating from
+// float64 amounts.
+//
+// params
+//   - amount - the amount in major units e.g. pounds
+//   - currency - ISO 4217 currency code
+//
+// returns
+//   - the money
+func MoneyFromFloat(amount float64, currency string) Money {
+	scale := math.Pow10(CurrencyExponent(currency))
+	return Money{Amount: int64(math.Round(amount * scale)), Currency: currency}
+}
+
+// CurrencyExponent returns the number of minor unit digits of an ISO 4217
+// currency, unknown currencies use 2.
+//
+// params
+//   - currency - ISO 4217 currency code
+//
+// returns
+//   - the number of minor unit digits
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exp
+	}
+
+	return 2
+}
+
+// Float64 returns the amount in major units as a float64. This is provided
+// for callers migrating from float64 amounts and should not be used for
+// arithmetic.
+//
+// returns
+//   - the amount in major units
+func (m Money) Float64() float64 {
+	if f, err := strconv.ParseFloat(m.Exact, 64); err == nil {
+		return f
+	}
+
+	return float64(m.Amount) / math.Pow10(CurrencyExponent(m.Currency))
+}
+
+// IsRounded returns whether Amount was rounded from an amount with more
+// decimal places than the currency, the original amount is in Exact.
+func (m Money) IsRounded() bool {
+	return m.Exact != ""
+}
+
+// IsZero returns whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative returns whether the amount is less than zero.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Neg returns the money with the sign of the amount flipped.
+func (m Money) Neg() Money {
+	neg := Money{Amount: -m.Amount, Currency: m.Currency}
+
+	switch {
+	case m.Exact == "":
+	case strings.HasPrefix(m.Exact, "-"):
+		neg.Exact = m.Exact[1:]
+	default:
+		neg.Exact = "-" + strings.TrimPrefix(m.Exact, "+")
+	}
+
+	return neg
+}
+
+// Abs returns the money with the absolute amount.
+func (m Money) Abs() Money {
+	if m.Amount < 0 {
+		return m.Neg()
+	}
+
+	return m
+}
+
+// Add returns the sum of m and o. A zero Money without a currency can be
+// added to any currency so it can be used as the starting total.
+//
+// params
+//   - o - the money to add
+//
+// returns
+//   - the sum
+//   - ErrCurrencyMismatch if the currencies differ, ErrMoneyOverflow if the
+//     sum is out of range
+func (m Money) Add(o Money) (Money, error) {
+	currency, err := m.commonCurrency(o)
+
+	if err != nil {
+		return Money{}, err
+	}
+
+	sum := m.Amount + o.Amount
+
+	if (o.Amount > 0 && sum < m.Amount) || (o.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrMoneyOverflow
+	}
+
+	return Money{Amount: sum, Currency: currency}, nil
+}
+
+// Sub returns the difference of m and o.
+//
+// params
+//   - o - the money to subtract
+//
+// returns
+//   - the difference
+//   - ErrCurrencyMismatch if the currencies differ, ErrMoneyOverflow if the
+//     difference is out of range
+func (m Money) Sub(o Money) (Money, error) {
+	if o.Amount == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+
+	return m.Add(o.Neg())
+}
+
+// Cmp compares m and o.
+//
+// params
+//   - o - the money to compare against
+//
+// returns
+//   - -1 if m is less than o, 0 if equal and +1 if greater
+//   - ErrCurrencyMismatch if the currencies differ
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := m.commonCurrency(o); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+// Decimal formats the amount in major units without the currency e.g.
+// "-12.34". Exact is returned when the amount was rounded.
+//
+// returns
+//   - the decimal amount
+func (m Money) Decimal() string {
+	if m.Exact != "" {
+		return m.Exact
+	}
+
+	exp := CurrencyExponent(m.Currency)
+	digits := strconv.FormatUint(absUint64(m.Amount), 10)
+
+	if exp > 0 {
+		if len(digits) <= exp {
+			digits = strings.Repeat("0", exp-len(digits)+1) + digits
+		}
+
+		digits = digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+	}
+
+	if m.Amount < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+// String formats the money e.g. "-12.34 GBP".
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+
+	return fmt.Sprintf("%s %s", m.Decimal(), m.Currency)
+}
+
+// MarshalJSON encodes the amount as a decimal number in major units, matching
+// the TrueLayer API.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON decodes a decimal number in major units. The currency must
+// already be set on m, models set it from their currency field before
+// decoding their amounts. Amounts with more decimal places than the currency
+// are rounded and kept in Exact rather than failing the response.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	money, err := parseMoney(string(bytes.Trim(data, `"`)), m.Currency)
+
+	if err != nil {
+		return fmt.Errorf("truelayer: decoding amount %s: %w", data, err)
+	}
+
+	m.Amount = money.Amount
+	m.Exact = money.Exact
+
+	return nil
+}
+
+// commonCurrency returns the currency of the result of combining m and o.
+//
+// params
+//   - o - the other money
+//
+// returns
+//   - the currency
+//   - ErrCurrencyMismatch if the currencies differ
+func (m Money) commonCurrency(o Money) (string, error) {
+	switch {
+	case m.Currency == o.Currency:
+		return m.Currency, nil
+	case m.Currency == "" && m.Amount == 0:
+		return o.Currency, nil
+	case o.Currency == "" && o.Amount == 0:
+		return m.Currency, nil
+	}
+
+	return "", ErrCurrencyMismatch
+}
+
+// pow10 returns 10^n as a big.Int.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// absUint64 returns the absolute value of n, handling math.MinInt64.
+func absUint64(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+
+	return uint64(n)
+}
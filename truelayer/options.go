@@ -0,0 +1,253 @@
+package truelayer
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPClient is an interface to define the methods required from any kind of
+// HTTP Client that will be used by the TrueLayer Client. *http.Client
+// satisfies this interface.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HTTPClientFunc is an adapter to allow the use of ordinary functions as an
+// HTTPClient.
+type HTTPClientFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HTTPClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the HTTP client used for every attempt of every request,
+// e.g. to add tracing, metrics or custom headers.
+type Middleware func(next HTTPClient) HTTPClient
+
+// Logger is the interface used to log requests, *log.Logger satisfies this
+// interface.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Option configures a TrueLayer client created with New.
+type Option func(t *TrueLayer)
+
+// WithEnvironment sets the hosts used by the client, defaults to
+// EnvironmentProduction.
+//
+// params
+//   - environment - the hosts for the TrueLayer client to use
+func WithEnvironment(environment Environment) Option {
+	return func(t *TrueLayer) {
+		t.environment = environment
+	}
+}
+
+// WithSandbox selects between the sandbox and the production environment.
+//
+// params
+//   - sandbox - true if using the sandbox environment
+func WithSandbox(sandbox bool) Option {
+	return func(t *TrueLayer) {
+		if sandbox {
+			t.environment = EnvironmentSandbox
+		} else {
+			t.environment = EnvironmentProduction
+		}
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests, defaults to a new
+// *http.Client. This is done to allow for mocking within user implementation to
+// allow for greater test coverage.
+//
+// params
+//   - httpClient - custom HTTP client for the TrueLayer client to use
+func WithHTTPClient(httpClient HTTPClient) Option {
+	return func(t *TrueLayer) {
+		t.httpClient = httpClient
+	}
+}
+
+// WithRetryPolicy sets the retry policy used for all requests, defaults to
+// DefaultRetryPolicy. Passing nil disables retries.
+//
+// params
+//   - policy - the retry policy
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(t *TrueLayer) {
+		t.retryPolicy = policy
+	}
+}
+
+// WithWebhookVerification enables or disables verifying the `Tl-Signature`
+// header in HandleAsyncWebhookRequest and handlers created with
+// NewWebhookHandler, defaults to enabled. Verification
+// should only be disabled when requests are verified elsewhere.
+//
+// params
+//   - enabled - true to verify webhook signatures
+func WithWebhookVerification(enabled bool) Option {
+	return func(t *TrueLayer) {
+		t.verifyWebhooks = enabled
+	}
+}
+
+// WithRawJSON keeps the JSON each model was decoded from in its Raw field,
+// defaults to disabled as it roughly doubles the memory used by large
+// responses.
+//
+// params
+//   - enabled - true to keep the raw JSON
+func WithRawJSON(enabled bool) Option {
+	return func(t *TrueLayer) {
+		t.rawJSON = enabled
+	}
+}
+
+// WithLogger logs the method, URL, status, request ID and duration of every
+// attempt of every request.
+//
+// params
+//   - logger - the logger to use
+func WithLogger(logger Logger) Option {
+	return func(t *TrueLayer) {
+		t.logger = logger
+	}
+}
+
+// WithUserAgent sets the `User-Agent` header sent with every request.
+//
+// params
+//   - userAgent - the user agent
+func WithUserAgent(userAgent string) Option {
+	return func(t *TrueLayer) {
+		t.userAgent = userAgent
+	}
+}
+
+// WithTimeout limits the total time of each call including any retries and
+// reading the response body. A deadline on the context passed to the
+// *WithContext methods still applies.
+//
+// params
+//   - timeout - the timeout, 0 disables the timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(t *TrueLayer) {
+		t.timeout = timeout
+	}
+}
+
+// WithMiddleware wraps the HTTP client with the provided middleware, the first
+// middleware is the outermost. Middleware is called for every attempt when a
+// request is retried.
+//
+// params
+//   - middleware - the middleware to add
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(t *TrueLayer) {
+		t.middleware = append(t.middleware, middleware...)
+	}
+}
+
+// wrapHTTPClient wraps the HTTP client with the middleware, the first
+// middleware is the outermost.
+//
+// params
+//   - httpClient - the HTTP client to wrap
+//   - middleware - the middleware to wrap it with
+//
+// returns
+//   - the wrapped HTTP client
+func wrapHTTPClient(httpClient HTTPClient, middleware []Middleware) HTTPClient {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		httpClient = middleware[i](httpClient)
+	}
+
+	return httpClient
+}
+
+// send executes a single attempt of the request, logging the result when a
+// logger is configured.
+//
+// params
+//   - req - the request to execute
+//
+// returns
+//   - the http response
+//   - any errors that have occurred
+func (t *TrueLayer) send(req *http.Request) (*http.Response, error) {
+	if t.logger == nil {
+		return t.httpClient.Do(req)
+	}
+
+	start := time.Now()
+	res, err := t.httpClient.Do(req)
+	took := time.Since(start).Round(time.Millisecond)
+	endpoint := req.URL.Host + req.URL.Path
+
+	if err != nil {
+		t.logger.Printf("truelayer: %s %s failed after %s: %v", req.Method, endpoint, took, err)
+		return res, err
+	}
+
+	requestID := res.Header.Get(HeaderRequestID)
+
+	if requestID == "" {
+		requestID = req.Header.Get(HeaderRequestID)
+	}
+
+	t.logger.Printf("truelayer: %s %s %d in %s (request id %q, correlation id %q)",
+		req.Method, endpoint, res.StatusCode, took, requestID, res.Header.Get(HeaderCorrelationID))
+
+	return res, err
+}
+
+// withTimeout applies the client's timeout to the request, the returned
+// function must be called with the result of the request so the timeout is
+// released once the response body has been closed.
+//
+// params
+//   - req - the request
+//
+// returns
+//   - the request with the timeout
+//   - function to call with the response
+func (t *TrueLayer) withTimeout(req *http.Request) (*http.Request, func(*http.Response, error) (*http.Response, error)) {
+	if t.timeout <= 0 {
+		return req, func(res *http.Response, err error) (*http.Response, error) {
+			return res, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	return req.WithContext(ctx), func(res *http.Response, err error) (*http.Response, error) {
+		if err != nil || res == nil {
+			cancel()
+			return res, err
+		}
+
+		res.Body = &cancelReadCloser{ReadCloser: res.Body, cancel: cancel}
+
+		return res, err
+	}
+}
+
+// cancelReadCloser cancels a context when the body is closed.
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the context.
+func (c *cancelReadCloser) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+
+	return err
+}
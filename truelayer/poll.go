@@ -0,0 +1,268 @@
+package truelayer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	AsyncStatusQueued    = "Queued"
+	AsyncStatusRunning   = "Running"
+	AsyncStatusSucceeded = "Succeeded"
+	AsyncStatusFailed    = "Failed"
+
+	ErrAsyncRequestNil     = StrError("async request must not be nil")
+	ErrPollAttemptsReached = StrError("async request did not complete within the maximum poll attempts")
+	ErrPollOptionsInvalid  = StrError("poll options must not be negative and multiplier must be at least 1")
+
+	// MinPollInterval is the shortest wait between polls, shorter intervals
+	// are raised to this.
+	MinPollInterval = 100 * time.Millisecond
+)
+
+// PollOptions configures how often an async request is polled whilst waiting
+// for it to complete.
+type PollOptions struct {
+	// Interval is the wait before the first poll, the default is used when 0
+	// and it is raised to MinPollInterval when shorter.
+	Interval time.Duration
+	// MaxInterval caps the wait between polls as it backs off, 0 is uncapped.
+	MaxInterval time.Duration
+	// Multiplier is applied to the wait after every poll, the default is used
+	// when 0 otherwise it must be at least 1.
+	Multiplier float64
+	// MaxAttempts is the maximum number of polls, 0 polls until the context
+	// is done.
+	MaxAttempts int
+}
+
+// DefaultPollOptions are used when nil options are passed to
+// PollAsyncRequest.
+var DefaultPollOptions = PollOptions{
+	Interval:    time.Second,
+	MaxInterval: 30 * time.Second,
+	Multiplier:  2,
+	MaxAttempts: 0,
+}
+
+// asyncResultStatus is the subset of a results response used to determine
+// whether an async task has completed.
+type asyncResultStatus struct {
+	Status           string `json:"status"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// PollAsyncRequest polls the TrueLayer results endpoint for the provided async
+// request, backing off between polls, until the task has either succeeded or
+// failed. This allows the async api to be used without exposing a webhook.
+//
+// params
+//   - accessToken - the access token used to trigger the async request
+//   - async - the response from triggering the async request
+//   - v - pointer to the response type of the endpoint that triggered the
+//     request e.g. *AccountsResponse, *AccountTransactionsResponse
+//   - opts - poll options, DefaultPollOptions are used when nil
+//
+// returns
+//   - errors from the api request, including failed tasks
+func (t *TrueLayer) PollAsyncRequest(accessToken string, async *AsyncRequestResponse, v interface{}, opts *PollOptions) error {
+	return t.PollAsyncRequestWithContext(context.Background(), accessToken, async, v, opts)
+}
+
+// PollAsyncRequestWithContext is the same as PollAsyncRequest but uses the
+// provided context for the underlying requests and to stop polling.
+//
+// params
+//   - ctx - context for the requests
+//   - accessToken - the access token used to trigger the async request
+//   - async - the response from triggering the async request
+//   - v - pointer to the response type of the endpoint that triggered the
+//     request e.g. *AccountsResponse, *AccountTransactionsResponse
+//   - opts - poll options, DefaultPollOptions are used when nil
+//
+// returns
+//   - errors from the api request, including failed tasks
+func (t *TrueLayer) PollAsyncRequestWithContext(ctx context.Context, accessToken string, async *AsyncRequestResponse, v interface{}, opts *PollOptions) error {
+	if async == nil {
+		return ErrAsyncRequestNil
+	}
+
+	if opts == nil {
+		opts = &DefaultPollOptions
+	}
+
+	opts, err := opts.withDefaults()
+
+	if err != nil {
+		return err
+	}
+
+	interval := opts.Interval
+
+	for attempt := 1; opts.MaxAttempts == 0 || attempt <= opts.MaxAttempts; attempt++ {
+		if err := sleepWithContext(ctx, interval); err != nil {
+			return err
+		}
+
+		done, err := t.getAsyncResult(ctx, accessToken, async.TaskID, v)
+
+		if err != nil || done {
+			return err
+		}
+
+		interval = time.Duration(float64(interval) * opts.Multiplier)
+
+		if opts.MaxInterval > 0 && interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+
+	return ErrPollAttemptsReached
+}
+
+// withDefaults validates the options, setting the defaults of any unset
+// options and raising the interval to MinPollInterval.
+//
+// returns
+//   - the options to poll with
+//   - ErrPollOptionsInvalid if any of the options are invalid
+func (o *PollOptions) withDefaults() (*PollOptions, error) {
+	opts := *o
+
+	if opts.Interval < 0 || opts.MaxInterval < 0 || opts.MaxAttempts < 0 || opts.Multiplier < 0 {
+		return nil, ErrPollOptionsInvalid
+	}
+
+	if opts.Multiplier == 0 {
+		opts.Multiplier = DefaultPollOptions.Multiplier
+	}
+
+	if opts.Multiplier < 1 {
+		return nil, ErrPollOptionsInvalid
+	}
+
+	if opts.Interval == 0 {
+		opts.Interval = DefaultPollOptions.Interval
+	}
+
+	if opts.Interval < MinPollInterval {
+		opts.Interval = MinPollInterval
+	}
+
+	if opts.MaxInterval > 0 && opts.MaxInterval < opts.Interval {
+		opts.MaxInterval = opts.Interval
+	}
+
+	return &opts, nil
+}
+
+// getAsyncResult fetches the results of an async task decoding them into v
+// once the task has succeeded.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token used to trigger the async request
+//   - taskID - the id of the async task
+//   - v - value to decode the results into
+//
+// returns
+//   - true if the task has completed
+//   - errors from the api request, including failed tasks
+func (t *TrueLayer) getAsyncResult(ctx context.Context, accessToken string, taskID string, v interface{}) (bool, error) {
+	res, err := t.doAsyncResultRequest(ctx, accessToken, taskID)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusAccepted {
+		return false, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+
+	if err != nil {
+		return false, err
+	}
+
+	status := asyncResultStatus{}
+	err = json.Unmarshal(body, &status)
+
+	if err != nil {
+		return false, err
+	}
+
+	switch status.Status {
+	case AsyncStatusQueued, AsyncStatusRunning:
+		return false, nil
+	case AsyncStatusFailed:
+		return true, &ErrorResponse{
+			ErrorMessage:     status.Error,
+			ErrorDescription: status.ErrorDescription,
+		}
+	}
+
+	return true, t.decodeJSON(bytes.NewReader(body), v)
+}
+
+// doAsyncResultRequest requests the results of an async task, the caller must
+// close the response body. The response status is 202 Accepted while the task
+// is still running.
+//
+// params
+//   - ctx - context for the request
+//   - accessToken - the access token used to trigger the async request
+//   - taskID - the id of the async task
+//
+// returns
+//   - the http response
+//   - errors from the api request
+func (t *TrueLayer) doAsyncResultRequest(ctx context.Context, accessToken string, taskID string) (*http.Response, error) {
+	u, err := buildURL(t.getBaseURL(), fmt.Sprintf(EndpointDataV1Results, taskID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.doAuthorizedGetRequest(ctx, u, accessToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 300 {
+		defer res.Body.Close()
+		return nil, parseErrorResponse(res)
+	}
+
+	return res, nil
+}
+
+// sleepWithContext waits for the provided duration or until the context is
+// done, whichever happens first.
+//
+// params
+//   - ctx - context to wait on
+//   - d - duration to wait
+//
+// returns
+//   - the context error if the context finished first
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -40,6 +40,30 @@ type AccountDirectDebitResponse struct {
 	Results []AccountDirectDebit `json:"results"`
 }
 
+type CardsResponse struct {
+	Results []Card `json:"results"`
+}
+
+type CardBalanceResponse struct {
+	Results []CardBalance `json:"results"`
+}
+
+type CardTransactionsResponse struct {
+	Results []CardTransaction `json:"results"`
+}
+
+type InfoResponse struct {
+	Results []Info `json:"results"`
+}
+
+type MeResponse struct {
+	Results []Me `json:"results"`
+}
+
+type BatchResponse struct {
+	Results BatchResult `json:"results"`
+}
+
 type AsyncRequestResponse struct {
 	ResultsURI string `json:"results_uri"`
 	Status     string `json:"status"`
@@ -0,0 +1,247 @@
+package truelayer
+
+import (
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried. Requests are retried
+// when a network error occurs or the response status is one of
+// RetryableStatusCodes, waiting with jittered exponential backoff between
+// attempts. A `Retry-After` header on the response takes precedence over the
+// backoff, when it asks for a longer wait than MaxBackoff the response is
+// returned rather than retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first, values
+	// less than 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the base wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential backoff and the longest `Retry-After`
+	// which is waited for.
+	MaxBackoff time.Duration
+	// RetryableStatusCodes are the response statuses which can be retried.
+	RetryableStatusCodes []int
+	// RetryNonIdempotent allows retrying requests other than GET and HEAD e.g.
+	// authentication requests and async requests, each attempt of an async
+	// request starts a new task.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is used by new clients, only idempotent requests are
+// retried. Async `GET` requests are not idempotent as each one starts a new
+// task.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	RetryableStatusCodes: []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// do executes the request using the HTTP client retrying according to the
+// client's retry policy. Any headers attached to the request's context and the
+// client's user agent are set before the request is sent.
+//
+// params
+//   - req - the request to execute
+//
+// returns
+//   - the http response
+//   - any errors that have occurred
+func (t *TrueLayer) do(req *http.Request) (*http.Response, error) {
+	setContextHeaders(req)
+
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	req, done := t.withTimeout(req)
+
+	return done(t.doWithRetries(req))
+}
+
+// doWithRetries executes the request using the HTTP client retrying according
+// to the client's retry policy.
+//
+// params
+//   - req - the request to execute
+//
+// returns
+//   - the http response
+//   - any errors that have occurred
+func (t *TrueLayer) doWithRetries(req *http.Request) (*http.Response, error) {
+	policy := t.retryPolicy
+
+	if policy == nil || policy.MaxAttempts < 2 || !policy.canRetry(req) {
+		return t.send(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+
+			if err != nil {
+				return nil, err
+			}
+
+			req.Body = body
+		}
+
+		res, err := t.send(req)
+
+		if attempt >= policy.MaxAttempts || !policy.shouldRetry(res, err) || req.Context().Err() != nil {
+			return res, err
+		}
+
+		wait, ok := policy.backoff(attempt, res)
+
+		if !ok {
+			return res, err
+		}
+
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		err = sleepWithContext(req.Context(), wait)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// canRetry returns whether the request can be safely retried.
+//
+// params
+//   - req - the request
+//
+// returns
+//   - true if the request can be retried
+func (p *RetryPolicy) canRetry(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if p.RetryNonIdempotent {
+		return true
+	}
+
+	if req.URL.Query().Get("async") == "true" {
+		return false
+	}
+
+	return req.Method == http.MethodGet || req.Method == http.MethodHead
+}
+
+// shouldRetry returns whether the result of an attempt should be retried.
+//
+// params
+//   - res - the response of the attempt
+//   - err - the error of the attempt
+//
+// returns
+//   - true if the attempt should be retried
+func (p *RetryPolicy) shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	for _, code := range p.RetryableStatusCodes {
+		if res.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff calculates the wait before the next attempt, honouring the
+// `Retry-After` header when present otherwise using jittered exponential
+// backoff.
+//
+// params
+//   - attempt - the attempt which has just failed, starting from 1
+//   - res - the response of the attempt (optional)
+//
+// returns
+//   - the wait before the next attempt
+//   - false if the `Retry-After` header asks for a longer wait than
+//     MaxBackoff and the request should not be retried
+func (p *RetryPolicy) backoff(attempt int, res *http.Response) (time.Duration, bool) {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+				return 0, false
+			}
+
+			return wait, true
+		}
+	}
+
+	wait := p.InitialBackoff
+
+	// doubling stops once MaxBackoff is reached so that a large number of
+	// attempts can't overflow
+	for i := 1; i < attempt && wait > 0 && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		if wait > math.MaxInt64/2 {
+			wait = math.MaxInt64
+			break
+		}
+
+		wait *= 2
+	}
+
+	if wait <= 0 || (p.MaxBackoff > 0 && wait > p.MaxBackoff) {
+		wait = p.MaxBackoff
+	}
+
+	if wait <= 0 {
+		return 0, true
+	}
+
+	half := wait / 2
+
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1)), true
+}
+
+// parseRetryAfter parses a `Retry-After` header value in either the delay
+// seconds or HTTP date format.
+//
+// params
+//   - value - the header value
+//
+// returns
+//   - the wait
+//   - true if the value was parsed
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,382 @@
+package truelayer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	HeaderTLSignature = "Tl-Signature"
+
+	signatureAlgES512 = "ES512"
+
+	// DefaultMaxWebhookBodySize is the default limit of the size of webhook
+	// request bodies read by VerifyRequest.
+	DefaultMaxWebhookBodySize = 1 << 20
+
+	ErrSignatureMissing        = StrError("webhook signature is missing")
+	ErrSignatureMalformed      = StrError("webhook signature is malformed")
+	ErrSignatureAlgorithm      = StrError("webhook signature algorithm is not supported")
+	ErrSignatureJKUNotAllowed  = StrError("webhook signature jku is not allowed")
+	ErrSignatureKeyNotFound    = StrError("webhook signature key was not found")
+	ErrSignatureMissingHeaders = StrError("webhook signature headers are missing from the request")
+	ErrSignatureInvalid        = StrError("webhook signature is invalid")
+	ErrSignatureBodyTooLarge   = StrError("webhook request body is too large")
+)
+
+// SignatureError is returned when a webhook request fails signature
+// verification. Reason is one of the ErrSignature* errors and can be checked
+// using errors.Is.
+type SignatureError struct {
+	Reason error
+	Err    error
+}
+
+// Error implements the `error` interface.
+func (e *SignatureError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s", e.Reason, e.Err)
+	}
+
+	return e.Reason.Error()
+}
+
+// Unwrap returns the reason the verification failed allowing the use of
+// errors.Is.
+func (e *SignatureError) Unwrap() error {
+	return e.Reason
+}
+
+// signatureHeader is the decoded protected header of a Tl-Signature JWS.
+type signatureHeader struct {
+	Alg       string `json:"alg"`
+	Kid       string `json:"kid"`
+	JKU       string `json:"jku"`
+	TLVersion string `json:"tl_version"`
+	TLHeaders string `json:"tl_headers"`
+}
+
+// jwk is a single JSON Web Key as returned from the TrueLayer JWKS endpoint.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// WebhookVerifier verifies the Tl-Signature header of TrueLayer webhook
+// requests. Signing keys are fetched from the TrueLayer JWKS endpoint and
+// cached, the keys are re-fetched when the cache expires or when a request is
+// signed with an unknown key to support key rotation.
+type WebhookVerifier struct {
+	t       *TrueLayer
+	jwksURL string
+
+	// CacheTTL is how long fetched keys are used before being re-fetched.
+	CacheTTL time.Duration
+	// MinRefreshInterval limits how often unknown keys can trigger a fetch.
+	MinRefreshInterval time.Duration
+	// MaxBodySize is the largest request body in bytes VerifyRequest reads,
+	// as the body is read before the signature is checked.
+	MaxBodySize int64
+
+	mu        sync.Mutex
+	keys      map[string]*ecdsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewWebhookVerifier creates a new WebhookVerifier which uses the JWKS
+// endpoint for the client's environment.
+//
+// returns
+//   - the webhook verifier
+func (t *TrueLayer) NewWebhookVerifier() *WebhookVerifier {
+	return &WebhookVerifier{
+		t:                  t,
+		jwksURL:            t.getJWKSURL(),
+		CacheTTL:           time.Hour,
+		MinRefreshInterval: time.Minute,
+		MaxBodySize:        DefaultMaxWebhookBodySize,
+	}
+}
+
+// VerifyRequest verifies the signature of the provided webhook request. The
+// request body is read and replaced so that it can still be decoded after
+// verification, bodies larger than MaxBodySize are rejected without being
+// read in full.
+//
+// params
+//   - r - the webhook request
+//
+// returns
+//   - *SignatureError if the signature is missing or invalid or the body is
+//     larger than MaxBodySize
+//   - errors reading the body or fetching the signing keys
+func (v *WebhookVerifier) VerifyRequest(r *http.Request) error {
+	if r.Body == nil {
+		return ErrRequestBodyNil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, v.MaxBodySize+1))
+	r.Body.Close()
+
+	if err != nil {
+		return err
+	}
+
+	if int64(len(body)) > v.MaxBodySize {
+		return &SignatureError{Reason: ErrSignatureBodyTooLarge}
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return v.Verify(r.Context(), r.Method, r.URL.Path, r.Header, body)
+}
+
+// Verify verifies a Tl-Signature against the provided request parts. The
+// signature must be a detached JWS over the method, path, the headers listed in
+// `tl_headers` and the body.
+//
+// params
+//   - ctx - context for fetching the signing keys
+//   - method - the request method
+//   - path - the request path
+//   - headers - the request headers including Tl-Signature
+//   - body - the raw request body
+//
+// returns
+//   - *SignatureError if the signature is missing or invalid
+//   - errors fetching the signing keys
+func (v *WebhookVerifier) Verify(ctx context.Context, method string, path string, headers http.Header, body []byte) error {
+	signature := headers.Get(HeaderTLSignature)
+
+	if signature == "" {
+		return &SignatureError{Reason: ErrSignatureMissing}
+	}
+
+	parts := strings.Split(signature, ".")
+
+	if len(parts) != 3 || parts[1] != "" {
+		return &SignatureError{Reason: ErrSignatureMalformed}
+	}
+
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+
+	if err != nil {
+		return &SignatureError{Reason: ErrSignatureMalformed, Err: err}
+	}
+
+	header := signatureHeader{}
+	err = json.Unmarshal(rawHeader, &header)
+
+	if err != nil {
+		return &SignatureError{Reason: ErrSignatureMalformed, Err: err}
+	}
+
+	if header.Alg != signatureAlgES512 {
+		return &SignatureError{Reason: ErrSignatureAlgorithm}
+	}
+
+	if header.JKU != "" && header.JKU != v.jwksURL {
+		return &SignatureError{Reason: ErrSignatureJKUNotAllowed}
+	}
+
+	rawSignature, err := base64.RawURLEncoding.DecodeString(parts[2])
+
+	if err != nil || len(rawSignature) != 132 {
+		return &SignatureError{Reason: ErrSignatureMalformed, Err: err}
+	}
+
+	payload, err := buildSignaturePayload(method, path, headers, header.TLHeaders, body)
+
+	if err != nil {
+		return err
+	}
+
+	key, err := v.getKey(ctx, header.Kid)
+
+	if err != nil {
+		return err
+	}
+
+	signingInput := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha512.Sum512([]byte(signingInput))
+
+	r := new(big.Int).SetBytes(rawSignature[:66])
+	s := new(big.Int).SetBytes(rawSignature[66:])
+
+	if !ecdsa.Verify(key, digest[:], r, s) {
+		return &SignatureError{Reason: ErrSignatureInvalid}
+	}
+
+	return nil
+}
+
+// buildSignaturePayload builds the payload signed by TrueLayer, this is the
+// method and path followed by each signed header and then the body.
+//
+// params
+//   - method - the request method
+//   - path - the request path
+//   - headers - the request headers
+//   - tlHeaders - comma separated list of signed header names
+//   - body - the raw request body
+//
+// returns
+//   - the signed payload
+//   - *SignatureError if a signed header is missing from the request
+func buildSignaturePayload(method string, path string, headers http.Header, tlHeaders string, body []byte) ([]byte, error) {
+	payload := bytes.Buffer{}
+	payload.WriteString(fmt.Sprintf("%s %s\n", strings.ToUpper(method), path))
+
+	for _, name := range strings.Split(tlHeaders, ",") {
+		name = strings.TrimSpace(name)
+
+		if name == "" {
+			continue
+		}
+
+		values, ok := headers[http.CanonicalHeaderKey(name)]
+
+		if !ok || len(values) == 0 {
+			return nil, &SignatureError{Reason: ErrSignatureMissingHeaders, Err: StrError(name)}
+		}
+
+		payload.WriteString(fmt.Sprintf("%s: %s\n", name, values[0]))
+	}
+
+	payload.Write(body)
+
+	return payload.Bytes(), nil
+}
+
+// getKey returns the cached signing key for the kid, re-fetching the JWKS when
+// the cache has expired or the kid is unknown.
+//
+// params
+//   - ctx - context for fetching the signing keys
+//   - kid - the key id
+//
+// returns
+//   - the signing key
+//   - *SignatureError if no key matches the kid
+//   - errors fetching the signing keys
+func (v *WebhookVerifier) getKey(ctx context.Context, kid string) (*ecdsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	age := time.Since(v.fetchedAt)
+	key, ok := v.keys[kid]
+
+	if v.keys == nil || age > v.CacheTTL || (!ok && age > v.MinRefreshInterval) {
+		err := v.fetchKeys(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok = v.keys[kid]
+	}
+
+	if !ok {
+		return nil, &SignatureError{Reason: ErrSignatureKeyNotFound}
+	}
+
+	return key, nil
+}
+
+// fetchKeys fetches the JWKS and replaces the cached keys. Must be called with
+// the mutex held.
+//
+// params
+//   - ctx - context for the request
+//
+// returns
+//   - errors from the api request or decoding the keys
+func (v *WebhookVerifier) fetchKeys(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := v.t.do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return parseErrorResponse(res)
+	}
+
+	jwks := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+	err = json.NewDecoder(res.Body).Decode(&jwks)
+
+	if err != nil {
+		return err
+	}
+
+	keys := map[string]*ecdsa.PublicKey{}
+
+	for _, k := range jwks.Keys {
+		if k.Kty != "EC" || k.Crv != "P-521" {
+			continue
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+
+		if err != nil {
+			return err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+
+		if err != nil {
+			return err
+		}
+
+		key := &ecdsa.PublicKey{
+			Curve: elliptic.P521(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			continue
+		}
+
+		keys[k.Kid] = key
+	}
+
+	v.keys = keys
+	v.fetchedAt = time.Now()
+
+	return nil
+}
+
+// getJWKSURL returns the JWKS URL for the client's environment. Using a utility
+// method to reduce code duplication.
+//
+// returns
+//   - the jwks url
+func (t *TrueLayer) getJWKSURL() string {
+	return t.environment.JWKSURL
+}
@@ -0,0 +1,187 @@
+package truelayer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testWebhookBody = `{"task_id":"task-1","status":"Succeeded","results_uri":"https://api.truelayer.com/data/v1/results/task-1"}`
+
+// newTestWebhookClient creates a client using a JWKS server which publishes
+// the public half of a generated P-521 key.
+func newTestWebhookClient(t *testing.T) (*TrueLayer, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(rw).Encode(map[string]interface{}{
+			"keys": []jwk{{
+				Kty: "EC",
+				Kid: "test-key",
+				Crv: "P-521",
+				X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 66))),
+				Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 66))),
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	environment := EnvironmentSandbox
+	environment.JWKSURL = server.URL
+
+	return New("id", "secret", WithEnvironment(environment), WithHTTPClient(server.Client())), key
+}
+
+// newSignedWebhookRequest creates a webhook request signed with the key in the
+// same way as TrueLayer.
+func newSignedWebhookRequest(t *testing.T, key *ecdsa.PrivateKey, jku string, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tl-Webhook-Timestamp", "2026-10-16T12:00:00Z")
+
+	header, err := json.Marshal(signatureHeader{
+		Alg:       signatureAlgES512,
+		Kid:       "test-key",
+		JKU:       jku,
+		TLVersion: "2",
+		TLHeaders: "X-Tl-Webhook-Timestamp",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := buildSignaturePayload(req.Method, req.URL.Path, req.Header, "X-Tl-Webhook-Timestamp", []byte(body))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedHeader := base64.RawURLEncoding.EncodeToString(header)
+	digest := sha512.Sum512([]byte(encodedHeader + "." + base64.RawURLEncoding.EncodeToString(payload)))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature := append(r.FillBytes(make([]byte, 66)), s.FillBytes(make([]byte, 66))...)
+	req.Header.Set(HeaderTLSignature, encodedHeader+".."+base64.RawURLEncoding.EncodeToString(signature))
+
+	return req
+}
+
+func TestHandleAsyncWebhookRequestVerifiesSignature(t *testing.T) {
+	client, key := newTestWebhookClient(t)
+	jku := client.Environment().JWKSURL
+
+	req, err := client.HandleAsyncWebhookRequest(newSignedWebhookRequest(t, key, jku, testWebhookBody))
+
+	if err != nil {
+		t.Fatalf("signed request: unexpected error %v", err)
+	}
+
+	if req.TaskID != "task-1" {
+		t.Errorf("signed request: got task id %q, want %q", req.TaskID, "task-1")
+	}
+
+	forged := newSignedWebhookRequest(t, key, jku, testWebhookBody)
+	forged.Body = io.NopCloser(strings.NewReader(strings.Replace(testWebhookBody, "task-1", "task-2", 1)))
+
+	_, err = client.HandleAsyncWebhookRequest(forged)
+
+	if !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("forged request: got error %v, want %v", err, ErrSignatureInvalid)
+	}
+
+	unsigned := newSignedWebhookRequest(t, key, jku, testWebhookBody)
+	unsigned.Header.Del(HeaderTLSignature)
+
+	_, err = client.HandleAsyncWebhookRequest(unsigned)
+
+	if !errors.Is(err, ErrSignatureMissing) {
+		t.Errorf("unsigned request: got error %v, want %v", err, ErrSignatureMissing)
+	}
+
+	_, err = client.HandleAsyncWebhookRequest(newSignedWebhookRequest(t, key, "https://attacker.example.com/jwks", testWebhookBody))
+
+	if !errors.Is(err, ErrSignatureJKUNotAllowed) {
+		t.Errorf("foreign jku: got error %v, want %v", err, ErrSignatureJKUNotAllowed)
+	}
+}
+
+func TestWebhookHandlerVerifiesSignature(t *testing.T) {
+	client, key := newTestWebhookClient(t)
+	jku := client.Environment().JWKSURL
+	received := make(chan *WebhookRequest, 1)
+	handler := client.NewWebhookHandler(func(req *WebhookRequest) { received <- req }, nil)
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, newSignedWebhookRequest(t, key, jku, testWebhookBody))
+
+	if rw.Code != http.StatusOK {
+		t.Fatalf("signed request: got status %d, want %d", rw.Code, http.StatusOK)
+	}
+
+	if req := <-received; req.TaskID != "task-1" {
+		t.Errorf("signed request: got task id %q, want %q", req.TaskID, "task-1")
+	}
+
+	unsigned := newSignedWebhookRequest(t, key, jku, testWebhookBody)
+	unsigned.Header.Del(HeaderTLSignature)
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, unsigned)
+
+	if rw.Code != http.StatusUnauthorized {
+		t.Errorf("unsigned request: got status %d, want %d", rw.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestHandleAsyncWebhookRequestVerificationDisabled(t *testing.T) {
+	client := New("id", "secret", WithWebhookVerification(false))
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testWebhookBody))
+
+	_, err := client.HandleAsyncWebhookRequest(req)
+
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestWebhookHandlerVerificationDisabled(t *testing.T) {
+	client := New("id", "secret", WithWebhookVerification(false))
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testWebhookBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rw := httptest.NewRecorder()
+	client.NewWebhookHandler(nil, nil).ServeHTTP(rw, req)
+
+	if rw.Code != http.StatusOK {
+		t.Errorf("got status %d, want %d", rw.Code, http.StatusOK)
+	}
+}
+
+func TestHandleAsyncWebhookRequestBodyTooLarge(t *testing.T) {
+	client, key := newTestWebhookClient(t)
+	body := `{"task_id":"` + strings.Repeat("a", DefaultMaxWebhookBodySize) + `"}`
+
+	_, err := client.HandleAsyncWebhookRequest(newSignedWebhookRequest(t, key, client.Environment().JWKSURL, body))
+
+	if !errors.Is(err, ErrSignatureBodyTooLarge) {
+		t.Errorf("got error %v, want %v", err, ErrSignatureBodyTooLarge)
+	}
+}
@@ -0,0 +1,127 @@
+package truelayer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	ErrTimestampInvalid = StrError("timestamp is not in a recognised format")
+
+	dateLayout = "2006-01-02"
+)
+
+// timestampLayouts are the formats observed from providers, timestamps
+// without an offset are treated as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+}
+
+// Timestamp is a tolerant timestamp used for provider supplied dates. Providers
+// return a mix of RFC 3339 timestamps, timestamps without an offset and plain
+// dates, all of which are decoded. The original value is kept in Raw.
+//
+// Many providers only supply the date of a transaction and return it as
+// midnight, HasTime is false for plain dates and for times at exactly midnight
+// so that the time of day is not relied upon.
+type Timestamp struct {
+	time.Time
+	// Raw is the value as returned by the API.
+	Raw string
+	// HasTime is true when the time of day is meaningful.
+	HasTime bool
+}
+
+// ParseTimestamp parses a timestamp in any of the formats returned by
+// providers.
+//
+// params
+//   - value - the timestamp
+//
+// returns
+//   - the timestamp
+//   - ErrTimestampInvalid if the format is not recognised
+func ParseTimestamp(value string) (Timestamp, error) {
+	ts := Timestamp{Raw: value}
+	trimmed := strings.TrimSpace(value)
+
+	if trimmed == "" {
+		return ts, nil
+	}
+
+	if t, err := time.Parse(dateLayout, trimmed); err == nil {
+		ts.Time = t
+		return ts, nil
+	}
+
+	for _, layout := range timestampLayouts {
+		t, err := time.Parse(layout, trimmed)
+
+		if err != nil {
+			continue
+		}
+
+		hour, min, sec := t.Clock()
+		ts.Time = t
+		ts.HasTime = hour != 0 || min != 0 || sec != 0 || t.Nanosecond() != 0
+
+		return ts, nil
+	}
+
+	return ts, ErrTimestampInvalid
+}
+
+// String formats the timestamp, plain dates are formatted without a time.
+func (ts Timestamp) String() string {
+	switch {
+	case ts.IsZero():
+		return ts.Raw
+	case !ts.HasTime:
+		return ts.Format(dateLayout)
+	}
+
+	return ts.Time.String()
+}
+
+// MarshalJSON encodes the timestamp as RFC 3339, or as a plain date when the
+// time of day is not meaningful. Timestamps which could not be parsed are
+// encoded as their raw value.
+func (ts Timestamp) MarshalJSON() ([]byte, error) {
+	switch {
+	case ts.IsZero():
+		return json.Marshal(ts.Raw)
+	case !ts.HasTime:
+		return json.Marshal(ts.Format(dateLayout))
+	}
+
+	return json.Marshal(ts.Format(time.RFC3339Nano))
+}
+
+// UnmarshalJSON decodes a timestamp in any of the formats returned by
+// providers. Unrecognised formats do not fail decoding, the value is kept in
+// Raw and the time is left zero.
+func (ts *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*ts = Timestamp{}
+		return nil
+	}
+
+	value := ""
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*ts, _ = ParseTimestamp(value)
+
+	return nil
+}
@@ -0,0 +1,467 @@
+package truelayer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	ErrTokenNotFound       = StrError("token not found")
+	ErrRefreshTokenMissing = StrError("token has expired and has no refresh token")
+)
+
+// Token is an access token with an absolute expiry, this is what is persisted
+// in a TokenStore.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// NewToken creates a Token from an AccessTokenResponse calculating the
+// absolute expiry from ExpiresIn.
+//
+// params
+//   - res - the access token response
+//
+// returns
+//   - the token
+func NewToken(res *AccessTokenResponse) *Token {
+	return &Token{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+		TokenType:    res.TokenType,
+		Expiry:       time.Now().Add(time.Duration(res.ExpiresIn) * time.Second),
+	}
+}
+
+// TokenStore persists tokens for a connection so rotated refresh tokens are
+// not lost. Load must return ErrTokenNotFound when no token exists for the key,
+// Delete must not return an error when no token exists.
+type TokenStore interface {
+	Load(ctx context.Context, key string) (*Token, error)
+	Save(ctx context.Context, key string, token *Token) error
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryTokenStore is a TokenStore which keeps tokens in memory.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]Token
+}
+
+// NewMemoryTokenStore creates a new empty MemoryTokenStore.
+//
+// returns
+//   - the token store
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: map[string]Token{},
+	}
+}
+
+// Load implements TokenStore.
+func (s *MemoryTokenStore) Load(ctx context.Context, key string) (*Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	token, ok := s.tokens[key]
+
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+
+	return &token, nil
+}
+
+// Save implements TokenStore.
+func (s *MemoryTokenStore) Save(ctx context.Context, key string, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[key] = *token
+
+	return nil
+}
+
+// Delete implements TokenStore.
+func (s *MemoryTokenStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tokens, key)
+
+	return nil
+}
+
+// FileTokenStore is a TokenStore which keeps each token as a JSON file within a
+// directory. Files are written atomically and with owner only permissions.
+type FileTokenStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileTokenStore creates a new FileTokenStore using the provided directory,
+// the directory is created when the first token is saved.
+//
+// params
+//   - dir - the directory to store tokens in
+//
+// returns
+//   - the token store
+func NewFileTokenStore(dir string) *FileTokenStore {
+	return &FileTokenStore{
+		dir: dir,
+	}
+}
+
+// Load implements TokenStore.
+func (s *FileTokenStore) Load(ctx context.Context, key string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(key))
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrTokenNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	token := &Token{}
+	err = json.Unmarshal(data, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// Save implements TokenStore.
+func (s *FileTokenStore) Save(ctx context.Context, key string, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(token)
+
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(s.dir, 0700)
+
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.dir, ".token-*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(data)
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), s.path(key))
+}
+
+// Delete implements TokenStore.
+func (s *FileTokenStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path(key))
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// path returns the file path for the key.
+//
+// params
+//   - key - the token key
+//
+// returns
+//   - the file path
+func (s *FileTokenStore) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".json")
+}
+
+// TokenSource provides a valid access token for a single connection. The
+// token is refreshed before it expires, concurrent refreshes are deduplicated
+// and rotated refresh tokens are saved to the TokenStore.
+//
+// A TokenSource can be passed to the *WithTokenSource data methods, or its
+// AccessToken passed to any other method.
+type TokenSource struct {
+	t     *TrueLayer
+	store TokenStore
+	key   string
+
+	// RefreshWindow is how long before expiry the token is refreshed.
+	RefreshWindow time.Duration
+
+	sem   chan struct{}
+	token *Token
+}
+
+// NewTokenSource creates a new TokenSource for the connection identified by
+// key. The token is loaded from the store when first needed, use SetToken to
+// store the token from GetAccessToken for a new connection.
+//
+// params
+//   - store - where the connection's token is persisted
+//   - key - identifies the connection within the store
+//
+// returns
+//   - the token source
+func (t *TrueLayer) NewTokenSource(store TokenStore, key string) *TokenSource {
+	return &TokenSource{
+		t:             t,
+		store:         store,
+		key:           key,
+		RefreshWindow: time.Minute,
+		sem:           make(chan struct{}, 1),
+	}
+}
+
+// SetToken saves the token from an AccessTokenResponse to the store and uses
+// it for subsequent requests.
+//
+// params
+//   - ctx - context for the store
+//   - res - the access token response
+//
+// returns
+//   - errors saving the token
+func (s *TokenSource) SetToken(ctx context.Context, res *AccessTokenResponse) error {
+	err := s.lock(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer s.unlock()
+
+	token := NewToken(res)
+	err = s.store.Save(ctx, s.key, token)
+
+	if err != nil {
+		return err
+	}
+
+	s.token = token
+
+	return nil
+}
+
+// Token returns a valid token, refreshing it if it has expired or is about to.
+//
+// params
+//   - ctx - context for the store and any refresh request
+//
+// returns
+//   - the token
+//   - errors loading, refreshing or saving the token
+func (s *TokenSource) Token(ctx context.Context) (*Token, error) {
+	err := s.lock(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.unlock()
+
+	if s.valid(s.token) {
+		return s.token, nil
+	}
+
+	// another process sharing the store may have already refreshed
+	token, err := s.store.Load(ctx, s.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if s.valid(token) {
+		s.token = token
+		return token, nil
+	}
+
+	return s.refresh(ctx, token)
+}
+
+// refresh refreshes the token and saves the refreshed token to the store. Must
+// be called with the token source locked.
+//
+// params
+//   - ctx - context for the store and refresh request
+//   - token - the token to refresh
+//
+// returns
+//   - the refreshed token
+//   - errors refreshing or saving the token
+func (s *TokenSource) refresh(ctx context.Context, token *Token) (*Token, error) {
+	if token.RefreshToken == "" {
+		return nil, ErrRefreshTokenMissing
+	}
+
+	res, err := s.t.RefreshAccessTokenWithContext(ctx, token.RefreshToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed := NewToken(res)
+
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+
+	err = s.store.Save(ctx, s.key, refreshed)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.token = refreshed
+
+	return refreshed, nil
+}
+
+// Revoke deletes the connection with TrueLayer using
+// DeleteConnectionWithTokenSource and then removes the token from the store.
+// If the connection has already been revoked the token is still removed from
+// the store. Any other error leaves the token in the store so the revoke can
+// be retried.
+//
+// params
+//   - ctx - context for the store and requests
+//
+// returns
+//   - errors deleting the connection or the token
+func (s *TokenSource) Revoke(ctx context.Context) error {
+	err := s.t.DeleteConnectionWithTokenSource(ctx, s)
+
+	if err != nil && !isRevokedError(err) {
+		return err
+	}
+
+	err = s.lock(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer s.unlock()
+
+	s.token = nil
+
+	return s.store.Delete(ctx, s.key)
+}
+
+// forceRefresh refreshes the token even if it has not expired. If the token
+// has already been replaced, by a refresh in this or another process sharing
+// the store, the replacement is used instead so that a rotated refresh token
+// is never used.
+//
+// params
+//   - ctx - context for the store and refresh request
+//   - stale - the token which was rejected
+//
+// returns
+//   - the refreshed token
+//   - errors loading, refreshing or saving the token
+func (s *TokenSource) forceRefresh(ctx context.Context, stale *Token) (*Token, error) {
+	err := s.lock(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.unlock()
+
+	token := s.token
+
+	if token == nil || token.AccessToken == stale.AccessToken {
+		token, err = s.store.Load(ctx, s.key)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if token.AccessToken != stale.AccessToken && s.valid(token) {
+		s.token = token
+		return token, nil
+	}
+
+	return s.refresh(ctx, token)
+}
+
+// AccessToken returns a valid access token, refreshing it if required.
+//
+// params
+//   - ctx - context for the store and any refresh request
+//
+// returns
+//   - the access token
+//   - errors loading, refreshing or saving the token
+func (s *TokenSource) AccessToken(ctx context.Context) (string, error) {
+	token, err := s.Token(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+// valid returns whether the token can be used without refreshing.
+func (s *TokenSource) valid(token *Token) bool {
+	return token != nil && token.AccessToken != "" && time.Now().Add(s.RefreshWindow).Before(token.Expiry)
+}
+
+// lock acquires the token source, waiting for any in-flight refresh.
+func (s *TokenSource) lock(ctx context.Context) error {
+	select {
+	case s.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// unlock releases the token source.
+func (s *TokenSource) unlock() {
+	<-s.sem
+}
+
+// isRevokedError returns whether the error shows that the connection has
+// already been revoked or that there is no connection to revoke.
+func isRevokedError(err error) bool {
+	return errors.Is(err, ErrConnectionRevoked) || errors.Is(err, ErrTokenNotFound)
+}
@@ -0,0 +1,113 @@
+package truelayer
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+)
+
+const (
+	ErrWebhookMethodNotAllowed = StrError("webhook request method must be POST")
+	ErrWebhookContentType      = StrError("webhook request content type must be application/json")
+)
+
+// WebhookHandler is an http.Handler which receives TrueLayer async webhook
+// requests. Requests are validated, their signature verified and decoded,
+// TrueLayer is acknowledged straight away and the decoded request is then
+// dispatched to the success or failure callback in a new goroutine.
+type WebhookHandler struct {
+	t         *TrueLayer
+	verifier  *WebhookVerifier
+	onSuccess func(req *WebhookRequest)
+	onFailure func(req *WebhookRequest, err error)
+}
+
+// NewWebhookHandler creates a new http.Handler for TrueLayer async webhook
+// requests. Signatures are verified using the client's WebhookVerifier, so
+// verification is disabled when the client was created with
+// WithWebhookVerification(false).
+//
+// params
+//   - onSuccess - called with requests for succeeded tasks (optional)
+//   - onFailure - called with requests for failed tasks and the task error
+//     (optional)
+//
+// returns
+//   - the webhook handler
+func (t *TrueLayer) NewWebhookHandler(onSuccess func(req *WebhookRequest), onFailure func(req *WebhookRequest, err error)) *WebhookHandler {
+	return &WebhookHandler{
+		t:         t,
+		verifier:  t.webhookVerifier,
+		onSuccess: onSuccess,
+		onFailure: onFailure,
+	}
+}
+
+// SetVerifier replaces the WebhookVerifier used to verify request signatures,
+// passing nil disables signature verification.
+//
+// params
+//   - verifier - the webhook verifier
+func (h *WebhookHandler) SetVerifier(verifier *WebhookVerifier) {
+	h.verifier = verifier
+}
+
+// ServeHTTP implements http.Handler. Non `POST` requests are rejected with
+// `405 Method Not Allowed`, non JSON requests with `415 Unsupported Media Type`,
+// unsigned or tampered requests with `401 Unauthorized` and undecodable
+// requests with `400 Bad Request`.
+//
+// params
+//   - rw - the response writer
+//   - r - the webhook request
+func (h *WebhookHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Set("Allow", http.MethodPost)
+		http.Error(rw, ErrWebhookMethodNotAllowed.Error(), http.StatusMethodNotAllowed)
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	if err != nil || mediaType != "application/json" {
+		http.Error(rw, ErrWebhookContentType.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	if h.verifier != nil {
+		err = h.verifier.VerifyRequest(r)
+
+		if err != nil {
+			status := http.StatusInternalServerError
+			sigErr := &SignatureError{}
+
+			if errors.As(err, &sigErr) {
+				status = http.StatusUnauthorized
+			}
+
+			http.Error(rw, err.Error(), status)
+			return
+		}
+	}
+
+	req, err := h.t.HandleAsyncWebhookRequestBody(r.Body)
+
+	if req == nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+
+	if err != nil {
+		if h.onFailure != nil {
+			go h.onFailure(req, err)
+		}
+
+		return
+	}
+
+	if h.onSuccess != nil {
+		go h.onSuccess(req)
+	}
+}